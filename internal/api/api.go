@@ -1,37 +1,93 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/adiatma85/golang-rest-template-api/internal/api/router/ingest"
 	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/health"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/mqtt"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/traffic"
 	"github.com/gin-gonic/gin"
 )
 
 // Set configuration
 // Change this func to "exported"  to make Test package can access it
-func SetConfiguration(configPath string) {
+func SetConfiguration(configPath string) error {
 	// Setup config from path
 	// Default is .env in root folder
 	config.Setup(configPath)
-	// Calling setup db
-	db.SetupDB()
 	gin.SetMode(config.GetConfig().Server.Mode)
-
+	// Calling setup db
+	return db.SetupDB()
 }
 
 // Run the new API with designated configuration
 func Run(configPath string) {
+	if err := run(configPath); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// Helper to run the api until SIGINT / SIGTERM. The workers are stopped by the deferred calls
+// in reverse order of their start, after the http server is drained, and the database is closed last
+func run(configPath string) error {
 	if configPath == "" {
 		configPath = ".env"
 	}
-	SetConfiguration(configPath)
+	// Api is not started without database, so the orchestrator restart it instead of routing to it
+	if err := SetConfiguration(configPath); err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.Println("failed to close database:", err)
+		}
+	}()
 	conf := config.GetConfig()
 
+	// Roles can only be managed by admin, so it is created from configuration instead of public registration
+	if err := SeedAdmin(conf.Admin); err != nil {
+		return fmt.Errorf("failed to create admin: %w", err)
+	}
+
+	// Traffic is written in background, the pending records are drained when the api stop
+	collector := traffic.GetCollector()
+	collector.Start()
+	defer collector.Stop()
+
+	// MQTT subscriber is optional, the api still run when the broker is unreachable
+	if conf.Mqtt.Enabled {
+		subscriber := mqtt.NewSubscriber(conf.Mqtt, conf.Ingest.MaxBatchSize)
+		if err := subscriber.Start(); err != nil {
+			log.Println("failed to start mqtt subscriber:", err)
+		} else {
+			fmt.Println("MQTT Subscriber listening on " + conf.Mqtt.Topic)
+		}
+		health.GetRegistry().Register(health.Check{Name: "mqtt", Optional: true, Check: subscriber.Check})
+		defer subscriber.Stop()
+	}
+
 	// Routing
 	web := v1.Setup()
+	ingest.Setup(web)
+	server := NewServer(web, conf.Server)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	fmt.Println("Go API REST Running on port " + conf.Server.Port)
 	fmt.Println("==================>")
-	_ = web.Run(":" + conf.Server.Port)
+	if err := server.ListenAndRun(ctx); err != nil {
+		return fmt.Errorf("failed to run api: %w", err)
+	}
+	fmt.Println("Go API REST stopped, draining background workers")
+	return nil
 }
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/alert"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var alertHandler *AlertHandler
+
+type AlertHandler struct {
+	AlertRepository         repository.AlertRepositoryInterface
+	PondThresholdRepository repository.PondThresholdRepositoryInterface
+	AlertEngine             alert.EngineInterface
+}
+
+type AlertHandlerInterface interface {
+	GetAllAlert(c *gin.Context)
+	Acknowledge(c *gin.Context)
+	Resolve(c *gin.Context)
+	GetAllThreshold(c *gin.Context)
+	UpsertThreshold(c *gin.Context)
+}
+
+// Func to get Alert Handler instance
+func GetAlertHandler() AlertHandlerInterface {
+	if alertHandler == nil {
+		alertHandler = &AlertHandler{
+			AlertRepository:         repository.GetAlertRepository(),
+			PondThresholdRepository: repository.GetPondThresholdRepository(),
+			AlertEngine:             alert.GetAlertEngine(),
+		}
+	}
+	return alertHandler
+}
+
+// HandlerFunc to Get All Alert of the tenant
+func (handler *AlertHandler) GetAllAlert(c *gin.Context) {
+	var getAllRequest validator.GetAllAlertRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	alertRepo := handler.AlertRepository.WithTenant(helpers.GetTenant(c))
+	filter := repository.AlertFilter{
+		PondId:   getAllRequest.PondId,
+		Status:   getAllRequest.Status,
+		Severity: getAllRequest.Severity,
+	}
+	alerts, err := alertRepo.GetAllPaginated(getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !alerts.CursorMode && alerts.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", alerts)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Acknowledge Alert (POST)
+func (handler *AlertHandler) Acknowledge(c *gin.Context) {
+	handler.changeStatus(c, models.AlertStatusAcknowledged)
+}
+
+// HandlerFunc to Resolve Alert (POST)
+func (handler *AlertHandler) Resolve(c *gin.Context) {
+	handler.changeStatus(c, models.AlertStatusResolved)
+}
+
+// Helper to change status of alert from ":alertId" param
+func (handler *AlertHandler) changeStatus(c *gin.Context, status string) {
+	alertRepo := handler.AlertRepository.WithTenant(helpers.GetTenant(c))
+	existedAlert, err := alertRepo.GetById(c.Param("alertId"))
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Resolved alert is final, and acknowledge can not be done twice
+	if existedAlert.Status == models.AlertStatusResolved || existedAlert.Status == status {
+		response := response.BuildFailedResponse("failed to update alert due to its current status", "alert is already "+existedAlert.Status)
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+
+	now := time.Now()
+	userId, _ := helpers.ParseUint(c.GetString("user_id"))
+	existedAlert.Status = status
+	switch status {
+	case models.AlertStatusAcknowledged:
+		existedAlert.AcknowledgedAt = &now
+		existedAlert.AcknowledgedBy = &userId
+	case models.AlertStatusResolved:
+		existedAlert.ResolvedAt = &now
+		existedAlert.ResolvedBy = &userId
+	}
+
+	if err := alertRepo.Update(existedAlert); err != nil {
+		response := response.BuildFailedResponse("failed to update alert", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success update alert to "+status, existedAlert)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All effective Threshold of Pond (configured and default)
+func (handler *AlertHandler) GetAllThreshold(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	thresholds, err := handler.AlertEngine.EffectiveThresholds(pond.ID)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success to fetch data", thresholds)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Create or Replace Threshold of Pond parameter (PUT)
+func (handler *AlertHandler) UpsertThreshold(c *gin.Context) {
+	var upsertThresholdRequest validator.UpsertPondThresholdRequest
+	err := c.ShouldBind(&upsertThresholdRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to update threshold due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if !upsertThresholdRequest.IsOrdered() {
+		response := response.BuildFailedResponse("failed to update threshold due to bad request", "bounds must be ordered as critical_min <= min <= max <= critical_max")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	threshold, err := handler.PondThresholdRepository.Upsert(models.PondThreshold{
+		PondId:      pond.ID,
+		Parameter:   upsertThresholdRequest.Parameter,
+		Min:         upsertThresholdRequest.Min,
+		Max:         upsertThresholdRequest.Max,
+		CriticalMin: upsertThresholdRequest.CriticalMin,
+		CriticalMax: upsertThresholdRequest.CriticalMax,
+	})
+	if err != nil {
+		response := response.BuildFailedResponse("failed to update threshold due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success update threshold", threshold)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/mashingan/smapping"
+	"gorm.io/gorm"
+)
+
+var authHandler *AuthHandler
+
+type AuthHandler struct {
+	UserRepository         repository.UserRepositoryInterface
+	RefreshTokenRepository repository.RefreshTokenRepositoryInterface
+	RevokedTokenRepository repository.RevokedTokenRepositoryInterface
+	PasswordHelper         crypto.PasswordCryptoHelper
+	JWTHelper              crypto.JWTCryptoHelper
+	RefreshTokenHelper     crypto.RefreshTokenCryptoHelper
+}
+
+type AuthHandlerInterface interface {
+	Register(c *gin.Context)
+	Login(c *gin.Context)
+	Refresh(c *gin.Context)
+	Logout(c *gin.Context)
+}
+
+// Func to get Auth Handler instance
+func GetAuthHandler() AuthHandlerInterface {
+	if authHandler == nil {
+		authHandler = &AuthHandler{
+			UserRepository:         repository.GetUserRepository(),
+			RefreshTokenRepository: repository.GetRefreshTokenRepository(),
+			RevokedTokenRepository: repository.GetRevokedTokenRepository(),
+			PasswordHelper:         crypto.GetPasswordCryptoHelper(),
+			JWTHelper:              crypto.GetJWTCrypto(),
+			RefreshTokenHelper:     crypto.GetRefreshTokenCryptoHelper(),
+		}
+	}
+	return authHandler
+}
+
+// HandlerFunc to Register new User (POST)
+func (handler *AuthHandler) Register(c *gin.Context) {
+	var registerRequest validator.RegisterRequest
+	err := c.ShouldBind(&registerRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to register new user due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	userRepo := handler.UserRepository
+
+	// Check if the email is already taken
+	if existedUser, _ := userRepo.GetByModel(models.User{Email: registerRequest.Email}); existedUser != nil {
+		response := response.BuildFailedResponse("failed to register new user due to duplicate resource", "duplicate entry")
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+
+	hashedPassword, err := handler.PasswordHelper.HashAndSalt([]byte(registerRequest.Password))
+	if err != nil {
+		response := response.BuildFailedResponse("failed to register new user due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	userModel := models.User{
+		Name:     registerRequest.Name,
+		Email:    registerRequest.Email,
+		Password: hashedPassword,
+		Role:     models.RoleViewer,
+	}
+
+	newUser, err := userRepo.Create(userModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to register new user due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	userDto := dto.UserResponseDto{}
+	smapping.FillStruct(&userDto, smapping.MapFields(&newUser))
+	response := response.BuildSuccessResponse("success register new user", userDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Login (POST)
+func (handler *AuthHandler) Login(c *gin.Context) {
+	var loginRequest validator.LoginRequest
+	err := c.ShouldBind(&loginRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to login due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	userRepo := handler.UserRepository
+	existedUser, _ := userRepo.GetByModel(models.User{Email: loginRequest.Email})
+
+	// Same response for unknown email and wrong password
+	if existedUser == nil || !handler.PasswordHelper.ComparePassword(existedUser.Password, []byte(loginRequest.Password)) {
+		response := response.BuildFailedResponse("failed to login due to invalid credential", "invalid email or password")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	tokenDto, err := handler.issueTokenPair(existedUser)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to login due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	loginDto := dto.LoginResponseDto{TokenResponseDto: tokenDto}
+	smapping.FillStruct(&loginDto.User, smapping.MapFields(existedUser))
+	response := response.BuildSuccessResponse("success to login", loginDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Refresh the token pair (POST).
+// The used refresh token is rotated (revoked) and new pair is issued
+func (handler *AuthHandler) Refresh(c *gin.Context) {
+	var refreshTokenRequest validator.RefreshTokenRequest
+	err := c.ShouldBind(&refreshTokenRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to refresh token due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	refreshTokenRepo := handler.RefreshTokenRepository
+	tokenHash := handler.RefreshTokenHelper.HashRefreshToken(refreshTokenRequest.RefreshToken)
+	existedToken, err := refreshTokenRepo.GetByHash(tokenHash)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to refresh token due to invalid token", "refresh token is not valid")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to refresh token", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	if existedToken.RevokedAt == nil && time.Now().After(existedToken.ExpiresAt) {
+		response := response.BuildFailedResponse("failed to refresh token due to invalid token", "refresh token is expired")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	// The token is rotated by conditional update, so concurrent refresh with the same token can not both succeed
+	revoked, err := refreshTokenRepo.Revoke(existedToken)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to refresh token due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// Reuse of rotated token means it is stolen, so cut every session of the user
+	if !revoked {
+		refreshTokenRepo.RevokeAllByUser(existedToken.UserId)
+		response := response.BuildFailedResponse("failed to refresh token due to invalid token", "refresh token has been revoked")
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	// Load the user again, so role changes are applied on the new token
+	tokenUser, err := handler.UserRepository.GetById(fmt.Sprint(existedToken.UserId))
+	if err != nil {
+		response := response.BuildFailedResponse("failed to refresh token due to invalid token", err.Error())
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	tokenDto, err := handler.issueTokenPair(tokenUser)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to refresh token due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success to refresh token", tokenDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Logout (POST).
+// Revoke the current access token, and the refresh token (or all of them)
+func (handler *AuthHandler) Logout(c *gin.Context) {
+	var logoutRequest validator.LogoutRequest
+	err := c.ShouldBind(&logoutRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to logout due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	revokedToken := models.RevokedToken{
+		Jti:       c.GetString("jti"),
+		ExpiresAt: time.Unix(c.GetInt64("token_expires_at"), 0),
+	}
+	if err := handler.RevokedTokenRepository.Create(revokedToken); err != nil {
+		response := response.BuildFailedResponse("failed to logout due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	handler.RevokedTokenRepository.DeleteExpired()
+
+	refreshTokenRepo := handler.RefreshTokenRepository
+	userId, _ := helpers.ParseUint(c.GetString("user_id"))
+	if logoutRequest.All {
+		err = refreshTokenRepo.RevokeAllByUser(userId)
+	} else if logoutRequest.RefreshToken != "" {
+		tokenHash := handler.RefreshTokenHelper.HashRefreshToken(logoutRequest.RefreshToken)
+		if existedToken, _ := refreshTokenRepo.GetByHash(tokenHash); existedToken != nil && existedToken.UserId == userId {
+			_, err = refreshTokenRepo.Revoke(existedToken)
+		}
+	}
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to logout due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Helper to issue new access token and persisted refresh token for user
+func (handler *AuthHandler) issueTokenPair(user *models.User) (dto.TokenResponseDto, error) {
+	organizationIds := make([]uint, 0, len(user.Organizations))
+	for _, organization := range user.Organizations {
+		organizationIds = append(organizationIds, organization.ID)
+	}
+
+	token, err := handler.JWTHelper.GenerateToken(fmt.Sprint(user.ID), user.Role, organizationIds)
+	if err != nil {
+		return dto.TokenResponseDto{}, err
+	}
+
+	refreshToken, err := handler.RefreshTokenHelper.GenerateRefreshToken()
+	if err != nil {
+		return dto.TokenResponseDto{}, err
+	}
+
+	refreshExpiresHour := config.GetConfig().Server.RefreshExpiresHour
+	_, err = handler.RefreshTokenRepository.Create(models.RefreshToken{
+		UserId:    user.ID,
+		TokenHash: handler.RefreshTokenHelper.HashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(time.Hour * time.Duration(refreshExpiresHour)),
+	})
+	if err != nil {
+		return dto.TokenResponseDto{}, err
+	}
+
+	return dto.TokenResponseDto{Token: token, RefreshToken: refreshToken}, nil
+}
@@ -0,0 +1,339 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var cycleHandler *CycleHandler
+
+// Allowed status transitions of cycle. Cycle only become harvested by its full harvest,
+// so the harvest is recorded and checked against the withdrawal period of the pond
+var cycleTransitions = map[string][]string{
+	models.CycleStatusPlanned:   {models.CycleStatusActive, models.CycleStatusFailed},
+	models.CycleStatusActive:    {models.CycleStatusFailed},
+	models.CycleStatusHarvested: {},
+	models.CycleStatusFailed:    {},
+}
+
+type CycleHandler struct {
+	CycleRepository repository.CycleRepositoryInterface
+	PondRepository  repository.PondRepositoryInterface
+}
+
+type CycleHandlerInterface interface {
+	CreateCycle(c *gin.Context)
+	GetAllCycle(c *gin.Context)
+	GetById(c *gin.Context)
+	Update(c *gin.Context)
+	ChangeStatus(c *gin.Context)
+}
+
+// Func to get Cycle Handler instance
+func GetCycleHandler() CycleHandlerInterface {
+	if cycleHandler == nil {
+		cycleHandler = &CycleHandler{
+			CycleRepository: repository.GetCycleRepository(),
+			PondRepository:  repository.GetPondRepository(),
+		}
+	}
+	return cycleHandler
+}
+
+// HandlerFunc to Create Cycle of Pond (POST)
+func (handler *CycleHandler) CreateCycle(c *gin.Context) {
+	var createCycleRequest validator.CreateCycleRequest
+	err := c.ShouldBind(&createCycleRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new cycle due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	cycleModel := models.Cycle{
+		PondId:               pond.ID,
+		Species:              createCycleRequest.Species,
+		StockingDate:         createCycleRequest.StockingDate,
+		InitialCount:         createCycleRequest.InitialCount,
+		InitialAverageWeight: createCycleRequest.InitialAverageWeight,
+		Status:               models.CycleStatusPlanned,
+	}
+	if createCycleRequest.Status != "" {
+		cycleModel.Status = createCycleRequest.Status
+	}
+
+	if err := validateStockingDensity(*pond, cycleModel.InitialCount); err != nil {
+		response := response.BuildFailedResponse("failed to add new cycle due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	// Only active pond without another active cycle can be stocked
+	newCycle := cycleModel
+	if cycleModel.Status == models.CycleStatusActive {
+		if !ensurePondActive(c, pond) {
+			return
+		}
+		err = handler.CycleRepository.SaveActive(&newCycle)
+	} else {
+		newCycle, err = handler.CycleRepository.Create(cycleModel)
+	}
+	if err != nil {
+		writeSaveCycleError(c, "failed to add new cycle", err)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new cycle instance to database", newCycle)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Cycle of Pond
+func (handler *CycleHandler) GetAllCycle(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	cycles, err := handler.CycleRepository.GetAllByPond(pond.ID)
+
+	// Internal server error
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// Error when no record found
+	if len(*cycles) == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", cycles)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get Cycle of Pond By Id
+func (handler *CycleHandler) GetById(c *gin.Context) {
+	cycle, ok := handler.getPondCycle(c)
+	if !ok {
+		return
+	}
+
+	response := response.BuildSuccessResponse("success to fetch data", cycle)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Update planned Cycle of Pond (PUT)
+func (handler *CycleHandler) Update(c *gin.Context) {
+	var updateCycleRequest validator.UpdateCycleRequest
+	err := c.ShouldBind(&updateCycleRequest)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to update cycle due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	existedCycle, ok := handler.getPondCycle(c)
+	if !ok {
+		return
+	}
+
+	if existedCycle.Status != models.CycleStatusPlanned {
+		response := response.BuildFailedResponse("failed to update cycle due to its current status", "only planned cycle can be updated")
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+
+	// Zero value means not updated
+	if updateCycleRequest.Species != "" {
+		existedCycle.Species = updateCycleRequest.Species
+	}
+	if !updateCycleRequest.StockingDate.IsZero() {
+		existedCycle.StockingDate = updateCycleRequest.StockingDate
+	}
+	if updateCycleRequest.InitialCount != 0 {
+		existedCycle.InitialCount = updateCycleRequest.InitialCount
+	}
+	if updateCycleRequest.InitialAverageWeight != 0 {
+		existedCycle.InitialAverageWeight = updateCycleRequest.InitialAverageWeight
+	}
+
+	if updateCycleRequest.InitialCount != 0 {
+		pond, err := handler.PondRepository.GetById(fmt.Sprint(existedCycle.PondId))
+		if err != nil {
+			response := response.BuildFailedResponse("failed to update a cycle", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+		if err := validateStockingDensity(*pond, existedCycle.InitialCount); err != nil {
+			response := response.BuildFailedResponse("failed to update cycle due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+	}
+
+	if err := handler.CycleRepository.Update(existedCycle); err != nil {
+		response := response.BuildFailedResponse("failed to update a cycle", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// HandlerFunc to Change Status of Cycle of Pond (PUT).
+// planned -> active | failed, active -> harvested | failed
+func (handler *CycleHandler) ChangeStatus(c *gin.Context) {
+	var changeStatusRequest validator.ChangeCycleStatusRequest
+	err := c.ShouldBind(&changeStatusRequest)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to change cycle status due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	existedCycle, ok := handler.getPondCycle(c)
+	if !ok {
+		return
+	}
+
+	if !isCycleTransitionAllowed(existedCycle.Status, changeStatusRequest.Status) {
+		response := response.BuildFailedResponse("failed to change cycle status due to invalid transition", existedCycle.Status+" cycle can not become "+changeStatusRequest.Status)
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+
+	// Only active pond without another active cycle can be stocked
+	if changeStatusRequest.Status == models.CycleStatusActive {
+		pond, err := handler.PondRepository.GetById(fmt.Sprint(existedCycle.PondId))
+		if err != nil {
+			response := response.BuildFailedResponse("failed to change cycle status", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+		if !ensurePondActive(c, pond) {
+			return
+		}
+	}
+
+	previousStatus := existedCycle.Status
+	existedCycle.Status = changeStatusRequest.Status
+	if changeStatusRequest.Status == models.CycleStatusFailed {
+		now := time.Now()
+		existedCycle.EndedAt = &now
+	}
+
+	// Pond follow its active cycle, failing a planned cycle does not change it
+	if existedCycle.Status == models.CycleStatusActive {
+		err = handler.CycleRepository.SaveActive(existedCycle)
+	} else {
+		err = handler.CycleRepository.Update(existedCycle)
+		if err == nil && previousStatus == models.CycleStatusActive {
+			err = handler.setPondOccupancy(existedCycle.PondId, models.PondOccupancyEmpty)
+		}
+	}
+	if err != nil {
+		writeSaveCycleError(c, "failed to change cycle status", err)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success change cycle status to "+existedCycle.Status, existedCycle)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to check whether the cycle status can change into the next status
+func isCycleTransitionAllowed(current, next string) bool {
+	for _, allowed := range cycleTransitions[current] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Helper to mark the pond as stocked or empty following its active cycle
+func (handler *CycleHandler) setPondOccupancy(pondId uint, occupancy string) error {
+	pond := models.Pond{Occupancy: occupancy}
+	pond.ID = pondId
+	return handler.PondRepository.Update(&pond)
+}
+
+// Helper to make sure pond is active (not drying or in maintenance) before it is stocked.
+// If it is not, the conflict response is written and false is returned
+func ensurePondActive(c *gin.Context, pond *models.Pond) bool {
+	if pond.Status != "" && pond.Status != models.PondStatusActive {
+		response := response.BuildFailedResponse("failed to activate cycle due to pond status", "pond is "+pond.Status)
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return false
+	}
+	return true
+}
+
+// Helper to write the failed response of saving cycle, conflict when the pond already has another active cycle
+func writeSaveCycleError(c *gin.Context, message string, err error) {
+	if errors.Is(err, repository.ErrActiveCycleExists) {
+		response := response.BuildFailedResponse("failed to activate cycle due to another active cycle", err.Error())
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+	response := response.BuildFailedResponse(message+" due to internal server error", err.Error())
+	c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+}
+
+// Helper to get cycle from ":cycleId" param that belong to pond from ":pondId" param.
+// If it does not exist, the failed response is written and false is returned
+func (handler *CycleHandler) getPondCycle(c *gin.Context) (*models.Cycle, bool) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return nil, false
+	}
+
+	cycleId, err := helpers.ParseUint(c.Param("cycleId"))
+	if err != nil || cycleId == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return nil, false
+	}
+
+	where := models.Cycle{PondId: pond.ID}
+	where.ID = cycleId
+	cycle, err := handler.CycleRepository.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return nil, false
+	}
+	return cycle, true
+}
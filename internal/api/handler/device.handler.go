@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var deviceHandler *DeviceHandler
+
+type DeviceHandler struct {
+	DeviceRepository repository.DeviceRepositoryInterface
+}
+
+type DeviceHandlerInterface interface {
+	CreateDevice(c *gin.Context)
+	GetAllDevice(c *gin.Context)
+	DeleteDevice(c *gin.Context)
+}
+
+// Func to get Device Handler instance
+func GetDeviceHandler() DeviceHandlerInterface {
+	if deviceHandler == nil {
+		deviceHandler = &DeviceHandler{
+			DeviceRepository: repository.GetDeviceRepository(),
+		}
+	}
+	return deviceHandler
+}
+
+// HandlerFunc to Register Device to Pond (POST), the api key is only returned here
+func (handler *DeviceHandler) CreateDevice(c *gin.Context) {
+	var createDeviceRequest validator.CreateDeviceRequest
+	err := c.ShouldBind(&createDeviceRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to register new device due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	deviceKeyHelper := crypto.GetDeviceKeyCryptoHelper()
+	apiKey, err := deviceKeyHelper.GenerateDeviceKey()
+	if err != nil {
+		response := response.BuildFailedResponse("failed to register new device due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	deviceModel := models.Device{
+		PondId:     pond.ID,
+		Name:       createDeviceRequest.Name,
+		ApiKeyHash: deviceKeyHelper.HashDeviceKey(apiKey),
+	}
+
+	newDevice, err := handler.DeviceRepository.Create(deviceModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to register new device due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	deviceDto := dto.DeviceResponseDto{Device: newDevice, ApiKey: apiKey}
+	response := response.BuildSuccessResponse("success register new device instance to database", deviceDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Device of Pond
+func (handler *DeviceHandler) GetAllDevice(c *gin.Context) {
+	var getAllRequest validator.PaginationRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	devices, err := handler.DeviceRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination())
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !devices.CursorMode && devices.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", devices)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Delete Device of Pond, its api key can not be used anymore
+func (handler *DeviceHandler) DeleteDevice(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	deviceRepo := handler.DeviceRepository
+	deviceId, err := helpers.ParseUint(c.Param("deviceId"))
+	if err != nil || deviceId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return
+	}
+
+	// The device must belong to the pond
+	where := models.Device{PondId: pond.ID}
+	where.ID = deviceId
+	existedDevice, err := deviceRepo.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	err = deviceRepo.Delete(existedDevice)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to delete a device", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
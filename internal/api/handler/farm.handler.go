@@ -6,9 +6,11 @@ import (
 	"net/http"
 
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/geojson"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
 	"github.com/adiatma85/golang-rest-template-api/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/mashingan/smapping"
@@ -25,6 +27,7 @@ type FarmHandlerInterface interface {
 	CreateFarm(c *gin.Context)
 	GetAllFarm(c *gin.Context)
 	GetById(c *gin.Context)
+	GetGeoJson(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
 }
@@ -51,14 +54,29 @@ func (handler *FarmHandler) CreateFarm(c *gin.Context) {
 		return
 	}
 
-	farmRepo := handler.FarmRepository
+	if err := validateFarmLocation(createFarmRequest.HasCompleteLocation(), createFarmRequest.Boundary); err != nil {
+		response := response.BuildFailedResponse("failed to add new farm due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
 	farmModel := &models.Farm{}
 
 	// smapping the struct
 	smapping.FillStruct(farmModel, smapping.MapFields(&createFarmRequest))
 
-	// Check if any duplicate is already exist
-	if existedFarm, _ := farmRepo.GetByModel(*farmModel); existedFarm != nil {
+	// The farm must belong to organization of the caller
+	organizationId, ok := resolveOrganizationId(c, createFarmRequest.OrganizationId)
+	if !ok {
+		response := response.BuildFailedResponse("failed to add new farm due to inaccessible organization", "organization is not accessible")
+		c.AbortWithStatusJSON(http.StatusForbidden, response)
+		return
+	}
+	farmModel.OrganizationId = organizationId
+
+	// Check if any duplicate (same name in the organization) is already exist
+	if existedFarm, _ := farmRepo.GetByModel(models.Farm{Name: farmModel.Name, OrganizationId: farmModel.OrganizationId}); existedFarm != nil {
 		// If exist, return response with "conflict"
 		response := response.BuildFailedResponse("failed to add new farm due to duplicate resource", "duplicate entry")
 		c.AbortWithStatusJSON(http.StatusConflict, response)
@@ -78,19 +96,45 @@ func (handler *FarmHandler) CreateFarm(c *gin.Context) {
 
 // HandlerFunc to Get All
 func (handler *FarmHandler) GetAllFarm(c *gin.Context) {
-	farmRepo := handler.FarmRepository
+	var getAllRequest validator.GetAllFarmRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
 
-	farms, err := farmRepo.GetAll()
+	filter := repository.FarmFilter{Name: getAllRequest.Name}
+	if getAllRequest.Bbox != "" {
+		boundingBox, err := helpers.ParseBoundingBox(getAllRequest.Bbox)
+		if err != nil {
+			response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+		filter.BoundingBox = boundingBox
+	}
+
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
+
+	farms, err := farmRepo.GetAllPaginated(getAllRequest.ToPagination(), filter)
 
-	// Internal server error
 	if err != nil {
-		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
 		return
 	}
 
-	// Error when no record found
-	if len(*farms) == 0 {
+	// Error when no record found (cursor mode does not count the rows)
+	if !farms.CursorMode && farms.TotalRows == 0 {
 		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
 		c.AbortWithStatusJSON(http.StatusNotFound, response)
 		return
@@ -103,7 +147,7 @@ func (handler *FarmHandler) GetAllFarm(c *gin.Context) {
 
 // HandlerFunc to Get By Id
 func (handler *FarmHandler) GetById(c *gin.Context) {
-	farmRepo := handler.FarmRepository
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
 
 	farm, err := farmRepo.GetById(c.Param("farmId"))
 
@@ -128,6 +172,32 @@ func (handler *FarmHandler) GetById(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// HandlerFunc to Get Farm and its Ponds as GeoJSON FeatureCollection.
+// It is not wrapped in the response so it can be plotted directly
+func (handler *FarmHandler) GetGeoJson(c *gin.Context) {
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
+
+	farm, err := farmRepo.GetById(c.Param("farmId"))
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	c.Header("Content-Type", "application/geo+json; charset=utf-8")
+	c.JSON(http.StatusOK, geojson.FarmFeatureCollection(*farm))
+}
+
 // Handlerfunc to Update
 func (handler *FarmHandler) Update(c *gin.Context) {
 	var updateFarmRequest validator.UpdateFarmRequest
@@ -139,7 +209,20 @@ func (handler *FarmHandler) Update(c *gin.Context) {
 		return
 	}
 
-	farmRepo := handler.FarmRepository
+	if err := validateFarmLocation(updateFarmRequest.HasCompleteLocation(), updateFarmRequest.Boundary); err != nil {
+		response := response.BuildFailedResponse("failed to update farm due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
+
+	// The farm must stay in organization of the caller
+	if updateFarmRequest.OrganizationId != 0 && !helpers.ContainsUint(helpers.GetTenant(c), updateFarmRequest.OrganizationId) {
+		response := response.BuildFailedResponse("failed to update farm due to inaccessible organization", "organization is not accessible")
+		c.AbortWithStatusJSON(http.StatusForbidden, response)
+		return
+	}
 
 	// Check whether "ID" is specified in payload or not
 	if updateFarmRequest.ID == 0 {
@@ -148,6 +231,14 @@ func (handler *FarmHandler) Update(c *gin.Context) {
 
 		smapping.FillStruct(farmModel, smapping.MapFields(&updateFarmRequest))
 
+		organizationId, ok := resolveOrganizationId(c, updateFarmRequest.OrganizationId)
+		if !ok {
+			response := response.BuildFailedResponse("failed to add new farm due to inaccessible organization", "organization is not accessible")
+			c.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+		farmModel.OrganizationId = organizationId
+
 		// Check whether there is error when creating
 		// Need new "small functional" so it does not duplicate
 		if newFarm, err := farmRepo.Create(*farmModel); err != nil {
@@ -183,7 +274,7 @@ func (handler *FarmHandler) Update(c *gin.Context) {
 
 // HandlerFunc to Delete
 func (handler *FarmHandler) Delete(c *gin.Context) {
-	farmRepo := handler.FarmRepository
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
 	existedFarm, err := farmRepo.GetById(c.Param("farmId"))
 
 	// If error
@@ -202,3 +293,44 @@ func (handler *FarmHandler) Delete(c *gin.Context) {
 	}
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Helper to resolve organization of new farm.
+// If not specified and the caller only belong to one organization, use it
+func resolveOrganizationId(c *gin.Context, organizationId uint) (uint, bool) {
+	tenant := helpers.GetTenant(c)
+	if organizationId == 0 && len(tenant) == 1 {
+		return tenant[0], true
+	}
+	return organizationId, organizationId != 0 && helpers.ContainsUint(tenant, organizationId)
+}
+
+// Helper to get farm from ":farmId" param that belong to the tenant of the caller.
+// If it does not exist, the failed response is written and false is returned
+func getTenantFarm(c *gin.Context) (*models.Farm, bool) {
+	farmRepo := repository.GetFarmRepository().WithTenant(helpers.GetTenant(c))
+	farm, err := farmRepo.GetById(c.Param("farmId"))
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch farm due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch farm", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return nil, false
+	}
+	return farm, true
+}
+
+// Helper to validate the location and boundary of farm
+func validateFarmLocation(hasCompleteLocation bool, boundary models.Polygon) error {
+	if !hasCompleteLocation {
+		return errors.New("latitude and longitude must be given together")
+	}
+	return boundary.Validate()
+}
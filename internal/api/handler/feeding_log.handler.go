@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var feedingLogHandler *FeedingLogHandler
+
+type FeedingLogHandler struct {
+	FeedingLogRepository repository.FeedingLogRepositoryInterface
+}
+
+type FeedingLogHandlerInterface interface {
+	CreateFeeding(c *gin.Context)
+	GetAllFeeding(c *gin.Context)
+	DeleteFeeding(c *gin.Context)
+	GetSummary(c *gin.Context)
+}
+
+// Func to get Feeding Log Handler instance
+func GetFeedingLogHandler() FeedingLogHandlerInterface {
+	if feedingLogHandler == nil {
+		feedingLogHandler = &FeedingLogHandler{
+			FeedingLogRepository: repository.GetFeedingLogRepository(),
+		}
+	}
+	return feedingLogHandler
+}
+
+// HandlerFunc to Create Feeding Log of Pond (POST)
+func (handler *FeedingLogHandler) CreateFeeding(c *gin.Context) {
+	var createFeedingRequest validator.CreateFeedingLogRequest
+	err := c.ShouldBind(&createFeedingRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new feeding log due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	feedingModel := models.FeedingLog{
+		PondId:   pond.ID,
+		FeedType: createFeedingRequest.FeedType,
+		Quantity: createFeedingRequest.Quantity,
+		FedAt:    time.Now(),
+	}
+	if createFeedingRequest.FedAt != nil {
+		feedingModel.FedAt = *createFeedingRequest.FedAt
+	}
+
+	newFeeding, err := handler.FeedingLogRepository.Create(feedingModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new feeding log due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new feeding log instance to database", newFeeding)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Feeding Log of Pond (by time range and feed type)
+func (handler *FeedingLogHandler) GetAllFeeding(c *gin.Context) {
+	var getAllRequest validator.GetAllFeedingLogRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.FeedingLogFilter{FeedType: getAllRequest.FeedType, From: getAllRequest.From, To: getAllRequest.To}
+	feedingLogs, err := handler.FeedingLogRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !feedingLogs.CursorMode && feedingLogs.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", feedingLogs)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Delete Feeding Log of Pond
+func (handler *FeedingLogHandler) DeleteFeeding(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	feedingRepo := handler.FeedingLogRepository
+	feedingId, err := helpers.ParseUint(c.Param("feedingId"))
+	if err != nil || feedingId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return
+	}
+
+	// The feeding log must belong to the pond
+	where := models.FeedingLog{PondId: pond.ID}
+	where.ID = feedingId
+	existedFeeding, err := feedingRepo.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	err = feedingRepo.Delete(existedFeeding)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to delete a feeding log", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// HandlerFunc to Get Feeding Summary of Pond, the cumulative feed and FCR in time range.
+// Starting and current biomass are supplied by the caller
+func (handler *FeedingLogHandler) GetSummary(c *gin.Context) {
+	var summaryRequest validator.FeedingSummaryRequest
+	if err := c.ShouldBindQuery(&summaryRequest); err != nil {
+		response := response.BuildFailedResponse("failed to compute feeding summary due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if !summaryRequest.From.IsZero() && !summaryRequest.To.IsZero() && summaryRequest.To.Before(summaryRequest.From) {
+		response := response.BuildFailedResponse("failed to compute feeding summary due to bad request", "to must not be before from")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	totals, err := handler.FeedingLogRepository.SumByPond(pond.ID, summaryRequest.From, summaryRequest.To)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to compute feeding summary due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	summaryDto := dto.FeedingSummaryResponseDto{
+		PondId:          pond.ID,
+		ByFeedType:      totals,
+		StartingBiomass: *summaryRequest.StartingBiomass,
+		CurrentBiomass:  *summaryRequest.CurrentBiomass,
+		BiomassGain:     *summaryRequest.CurrentBiomass - *summaryRequest.StartingBiomass,
+	}
+	if !summaryRequest.From.IsZero() {
+		summaryDto.From = &summaryRequest.From
+	}
+	if !summaryRequest.To.IsZero() {
+		summaryDto.To = &summaryRequest.To
+	}
+	for _, total := range totals {
+		summaryDto.TotalFeed += total.Quantity
+		summaryDto.Feedings += total.Feedings
+	}
+	if fcr, ok := helpers.FeedConversionRatio(summaryDto.TotalFeed, summaryDto.StartingBiomass, summaryDto.CurrentBiomass); ok {
+		summaryDto.Fcr = &fcr
+	}
+
+	response := response.BuildSuccessResponse("success to compute feeding summary", summaryDto)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var growthSampleHandler *GrowthSampleHandler
+
+type GrowthSampleHandler struct {
+	GrowthSampleRepository repository.GrowthSampleRepositoryInterface
+	CycleRepository        repository.CycleRepositoryInterface
+}
+
+type GrowthSampleHandlerInterface interface {
+	CreateSample(c *gin.Context)
+	GetAllSample(c *gin.Context)
+	DeleteSample(c *gin.Context)
+}
+
+// Func to get Growth Sample Handler instance
+func GetGrowthSampleHandler() GrowthSampleHandlerInterface {
+	if growthSampleHandler == nil {
+		growthSampleHandler = &GrowthSampleHandler{
+			GrowthSampleRepository: repository.GetGrowthSampleRepository(),
+			CycleRepository:        repository.GetCycleRepository(),
+		}
+	}
+	return growthSampleHandler
+}
+
+// HandlerFunc to Create Growth Sample of the active Cycle of Pond (POST)
+func (handler *GrowthSampleHandler) CreateSample(c *gin.Context) {
+	var createSampleRequest validator.CreateGrowthSampleRequest
+	err := c.ShouldBind(&createSampleRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new sample due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	// Sample is taken from the active cycle
+	activeCycle, err := handler.CycleRepository.GetActiveByPond(pond.ID)
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case pond is not stocked
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to add new sample due to no active cycle", "pond does not have active cycle")
+			c.AbortWithStatusJSON(http.StatusConflict, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to add new sample due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	sampleModel := models.GrowthSample{
+		PondId:         pond.ID,
+		CycleId:        activeCycle.ID,
+		SampledAt:      time.Now(),
+		SampleCount:    createSampleRequest.SampleCount,
+		TotalWeight:    createSampleRequest.TotalWeight,
+		EstimatedCount: createSampleRequest.EstimatedCount,
+	}
+	if createSampleRequest.SampledAt != nil {
+		sampleModel.SampledAt = *createSampleRequest.SampledAt
+	}
+
+	if sampleModel.SampledAt.Before(activeCycle.StockingDate) {
+		response := response.BuildFailedResponse("failed to add new sample due to bad request", "sampled_at must not be before stocking date of the cycle")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	newSample, err := handler.GrowthSampleRepository.Create(sampleModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new sample due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new sample instance to database", newSample)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Growth Sample of Pond (optionally of a cycle)
+func (handler *GrowthSampleHandler) GetAllSample(c *gin.Context) {
+	var getAllRequest validator.GetAllGrowthSampleRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.GrowthSampleFilter{CycleId: getAllRequest.CycleId}
+	samples, err := handler.GrowthSampleRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !samples.CursorMode && samples.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", samples)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Delete Growth Sample of Pond
+func (handler *GrowthSampleHandler) DeleteSample(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	sampleRepo := handler.GrowthSampleRepository
+	sampleId, err := helpers.ParseUint(c.Param("sampleId"))
+	if err != nil || sampleId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return
+	}
+
+	// The sample must belong to the pond
+	where := models.GrowthSample{PondId: pond.ID}
+	where.ID = sampleId
+	existedSample, err := sampleRepo.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	err = sampleRepo.Delete(existedSample)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to delete a sample", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/harvest"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var harvestHandler *HarvestHandler
+
+type HarvestHandler struct {
+	HarvestRepository      repository.HarvestRepositoryInterface
+	CycleRepository        repository.CycleRepositoryInterface
+	MortalityLogRepository repository.MortalityLogRepositoryInterface
+	FarmRepository         repository.FarmRepositoryInterface
+	TreatmentLogRepository repository.TreatmentLogRepositoryInterface
+}
+
+type HarvestHandlerInterface interface {
+	CreateHarvest(c *gin.Context)
+	GetAllHarvest(c *gin.Context)
+	GetFarmYield(c *gin.Context)
+}
+
+// Func to get Harvest Handler instance
+func GetHarvestHandler() HarvestHandlerInterface {
+	if harvestHandler == nil {
+		harvestHandler = &HarvestHandler{
+			HarvestRepository:      repository.GetHarvestRepository(),
+			CycleRepository:        repository.GetCycleRepository(),
+			MortalityLogRepository: repository.GetMortalityLogRepository(),
+			FarmRepository:         repository.GetFarmRepository(),
+			TreatmentLogRepository: repository.GetTreatmentLogRepository(),
+		}
+	}
+	return harvestHandler
+}
+
+// HandlerFunc to Create Harvest of the active Cycle of Pond (POST).
+// Full harvest end the cycle and mark the pond as empty
+func (handler *HarvestHandler) CreateHarvest(c *gin.Context) {
+	var createHarvestRequest validator.CreateHarvestRequest
+	err := c.ShouldBind(&createHarvestRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new harvest due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	// Harvest is taken from the active cycle
+	activeCycle, err := handler.CycleRepository.GetActiveByPond(pond.ID)
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case pond is not stocked
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to add new harvest due to no active cycle", "pond does not have active cycle")
+			c.AbortWithStatusJSON(http.StatusConflict, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to add new harvest due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	harvestModel := models.Harvest{
+		PondId:      pond.ID,
+		CycleId:     activeCycle.ID,
+		Type:        createHarvestRequest.Type,
+		HarvestedAt: time.Now(),
+		Quantity:    createHarvestRequest.Quantity,
+		TotalWeight: createHarvestRequest.TotalWeight,
+		SizeGrade:   createHarvestRequest.SizeGrade,
+		PricePerKg:  createHarvestRequest.PricePerKg,
+	}
+	if createHarvestRequest.HarvestedAt != nil {
+		harvestModel.HarvestedAt = *createHarvestRequest.HarvestedAt
+	}
+
+	if harvestModel.HarvestedAt.Before(activeCycle.StockingDate) {
+		response := response.BuildFailedResponse("failed to add new harvest due to bad request", "harvested_at must not be before stocking date of the cycle")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	// Stock must not be harvested while a treatment is inside its withdrawal period
+	if !ensureOutsideWithdrawal(c, handler.TreatmentLogRepository, pond.ID, harvestModel.HarvestedAt, "add new harvest") {
+		return
+	}
+
+	// Harvested stock must not exceed the stock that is still in the pond
+	remaining, err := remainingStock(handler.MortalityLogRepository, handler.HarvestRepository, activeCycle)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new harvest due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	if harvestModel.Quantity > remaining {
+		response := response.BuildFailedResponse("failed to add new harvest due to bad request", fmt.Sprintf("quantity must not exceed the remaining stock of %d", remaining))
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	newHarvest, err := handler.HarvestRepository.Create(harvestModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new harvest due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new harvest instance to database", newHarvest)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Harvest of Pond (by time range and cycle)
+func (handler *HarvestHandler) GetAllHarvest(c *gin.Context) {
+	var getAllRequest validator.GetAllHarvestRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.HarvestFilter{CycleId: getAllRequest.CycleId, From: getAllRequest.From, To: getAllRequest.To}
+	harvests, err := handler.HarvestRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !harvests.CursorMode && harvests.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", harvests)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get Yield of Farm, the harvested kilogram, revenue and days of culture across its ponds
+func (handler *HarvestHandler) GetFarmYield(c *gin.Context) {
+	var yieldRequest validator.FarmYieldRequest
+	if err := c.ShouldBindQuery(&yieldRequest); err != nil {
+		response := response.BuildFailedResponse("failed to compute yield due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	farmRepo := handler.FarmRepository.WithTenant(helpers.GetTenant(c))
+	farm, err := farmRepo.GetById(c.Param("farmId"))
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Ponds are preloaded with the farm
+	pondIds := make([]uint, 0, len(farm.Ponds))
+	for _, pond := range farm.Ponds {
+		pondIds = append(pondIds, pond.ID)
+	}
+
+	harvests, err := handler.HarvestRepository.GetAllByPonds(pondIds, yieldRequest.From, yieldRequest.To)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to compute yield due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	report := harvest.BuildYieldReport(*farm, *harvests)
+	response := response.BuildSuccessResponse("success to compute yield", report)
+	c.JSON(http.StatusOK, response)
+}
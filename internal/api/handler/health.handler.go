@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/health"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+var healthHandler *HealthHandler
+
+// Time the result of storage check is reused, the storage api is rate limited
+const storageCheckTtl = 30 * time.Second
+
+type HealthHandler struct {
+	Registry *health.Registry
+}
+
+type HealthHandlerInterface interface {
+	Liveness(c *gin.Context)
+	Readiness(c *gin.Context)
+}
+
+// Func to get Health Handler instance, the database and storage checks are registered on creation
+func GetHealthHandler() HealthHandlerInterface {
+	if healthHandler == nil {
+		healthHandler = &HealthHandler{
+			Registry: health.GetRegistry(),
+		}
+		healthHandler.Registry.Register(health.Check{Name: "database", Check: db.Ping})
+		healthHandler.Registry.Register(health.Check{Name: "migration", Check: func(ctx context.Context) error {
+			return db.MigrationError()
+		}})
+
+		// Storage is only checked when it is configured
+		cloudinary := config.GetConfig().Cloudinary
+		if cloudinary.CloudName != "" {
+			pingUrl := fmt.Sprintf("https://api.cloudinary.com/v1_1/%s/ping", cloudinary.CloudName)
+			healthHandler.Registry.Register(health.Check{
+				Name:     "storage",
+				Optional: true,
+				Check:    health.CachedCheck(health.HttpGetCheck(http.DefaultClient, pingUrl, cloudinary.ApiKey, cloudinary.ApiSecret), storageCheckTtl),
+			})
+		}
+	}
+	return healthHandler
+}
+
+// HandlerFunc to tell the process is up, it does not check any dependency
+func (handler *HealthHandler) Liveness(c *gin.Context) {
+	response := response.BuildSuccessResponse("alive", gin.H{"status": health.StatusOk})
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to tell the api can serve traffic, with the status of every check.
+// Failure of optional check is reported but the api is still ready.
+// The endpoint is not authenticated, so the error of failed check is only logged
+func (handler *HealthHandler) Readiness(c *gin.Context) {
+	report := handler.Registry.Run(c.Request.Context())
+	for name, result := range report.Checks {
+		if result.Status == health.StatusFail {
+			log.Printf("readiness check %s failed: %s\n", name, result.Error)
+		}
+	}
+
+	if report.Status != health.StatusOk {
+		response := response.BuildFailedResponse("not ready", report.WithoutErrors())
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("ready", report.WithoutErrors())
+	c.JSON(http.StatusOK, response)
+}
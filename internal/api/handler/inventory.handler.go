@@ -0,0 +1,412 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var inventoryHandler *InventoryHandler
+
+type InventoryHandler struct {
+	InventoryItemRepository repository.InventoryItemRepositoryInterface
+	StockMovementRepository repository.StockMovementRepositoryInterface
+}
+
+type InventoryHandlerInterface interface {
+	CreateItem(c *gin.Context)
+	GetAllItem(c *gin.Context)
+	GetItemById(c *gin.Context)
+	UpdateItem(c *gin.Context)
+	DeleteItem(c *gin.Context)
+	CreateMovement(c *gin.Context)
+	GetAllMovement(c *gin.Context)
+}
+
+// Func to get Inventory Handler instance
+func GetInventoryHandler() InventoryHandlerInterface {
+	if inventoryHandler == nil {
+		inventoryHandler = &InventoryHandler{
+			InventoryItemRepository: repository.GetInventoryItemRepository(),
+			StockMovementRepository: repository.GetStockMovementRepository(),
+		}
+	}
+	return inventoryHandler
+}
+
+// HandlerFunc to Create Inventory Item of Farm (POST)
+func (handler *InventoryHandler) CreateItem(c *gin.Context) {
+	var createItemRequest validator.CreateInventoryItemRequest
+	err := c.ShouldBind(&createItemRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new inventory item due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	farm, ok := getTenantFarm(c)
+	if !ok {
+		return
+	}
+
+	// Name of item is unique in the farm
+	_, err = handler.InventoryItemRepository.GetByModel(models.InventoryItem{FarmId: farm.ID, Name: createItemRequest.Name})
+	if err == nil {
+		response := response.BuildFailedResponse("failed to add new inventory item due to conflict", "item with the same name already exist in the farm")
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+
+	itemModel := models.InventoryItem{
+		FarmId:            farm.ID,
+		Name:              createItemRequest.Name,
+		Category:          createItemRequest.Category,
+		Unit:              createItemRequest.Unit,
+		LowStockThreshold: createItemRequest.LowStockThreshold,
+	}
+
+	newItem, err := handler.InventoryItemRepository.Create(itemModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new inventory item due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	itemDto := dto.InventoryItemResponseDto{InventoryItem: newItem, LowStock: newItem.IsLowStock(0)}
+	response := response.BuildSuccessResponse("success add new inventory item instance to database", itemDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Inventory Item of Farm with its on-hand balance
+func (handler *InventoryHandler) GetAllItem(c *gin.Context) {
+	var getAllRequest validator.GetAllInventoryItemRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	farm, ok := getTenantFarm(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.InventoryItemFilter{Name: getAllRequest.Name, Category: getAllRequest.Category, LowStock: getAllRequest.LowStock}
+	items, err := handler.InventoryItemRepository.GetAllByFarm(farm.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !items.CursorMode && items.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Balance of the fetched page is computed at once
+	rows := *items.Rows.(*[]models.InventoryItem)
+	itemIds := make([]uint, 0, len(rows))
+	for _, item := range rows {
+		itemIds = append(itemIds, item.ID)
+	}
+	balances, err := handler.InventoryItemRepository.GetBalances(itemIds)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	itemDtos := make([]dto.InventoryItemResponseDto, 0, len(rows))
+	for _, item := range rows {
+		onHand := balances[item.ID]
+		itemDtos = append(itemDtos, dto.InventoryItemResponseDto{InventoryItem: item, OnHand: onHand, LowStock: item.IsLowStock(onHand)})
+	}
+	items.Rows = itemDtos
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", items)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get Inventory Item of Farm by Id with its on-hand balance
+func (handler *InventoryHandler) GetItemById(c *gin.Context) {
+	item, ok := handler.getFarmItem(c)
+	if !ok {
+		return
+	}
+
+	itemDto, err := handler.withBalance(*item)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success to fetch data", itemDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Update Inventory Item of Farm
+func (handler *InventoryHandler) UpdateItem(c *gin.Context) {
+	var updateItemRequest validator.UpdateInventoryItemRequest
+	if err := c.ShouldBind(&updateItemRequest); err != nil {
+		response := response.BuildFailedResponse("failed to update inventory item due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	item, ok := handler.getFarmItem(c)
+	if !ok {
+		return
+	}
+
+	// Renamed item must not collide with another item of the farm
+	if updateItemRequest.Name != "" && updateItemRequest.Name != item.Name {
+		_, err := handler.InventoryItemRepository.GetByModel(models.InventoryItem{FarmId: item.FarmId, Name: updateItemRequest.Name})
+		if err == nil {
+			response := response.BuildFailedResponse("failed to update inventory item due to conflict", "item with the same name already exist in the farm")
+			c.AbortWithStatusJSON(http.StatusConflict, response)
+			return
+		}
+	}
+
+	if updateItemRequest.Name != "" {
+		item.Name = updateItemRequest.Name
+	}
+	if updateItemRequest.Category != "" {
+		item.Category = updateItemRequest.Category
+	}
+	if updateItemRequest.Unit != "" {
+		item.Unit = updateItemRequest.Unit
+	}
+	if updateItemRequest.LowStockThreshold != nil {
+		item.LowStockThreshold = updateItemRequest.LowStockThreshold
+	}
+
+	if err := handler.InventoryItemRepository.Update(item); err != nil {
+		response := response.BuildFailedResponse("failed to update inventory item due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// HandlerFunc to Delete Inventory Item of Farm
+func (handler *InventoryHandler) DeleteItem(c *gin.Context) {
+	item, ok := handler.getFarmItem(c)
+	if !ok {
+		return
+	}
+
+	if err := handler.InventoryItemRepository.Delete(item); err != nil {
+		response := response.BuildFailedResponse("failed to delete an inventory item", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// HandlerFunc to Create Stock Movement of Inventory Item (POST).
+// Consumption is stored as negative quantity and can not take more than the on-hand balance
+func (handler *InventoryHandler) CreateMovement(c *gin.Context) {
+	var createMovementRequest validator.CreateStockMovementRequest
+	err := c.ShouldBind(&createMovementRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new stock movement due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	farm, ok := getTenantFarm(c)
+	if !ok {
+		return
+	}
+	item, ok := handler.getItemOfFarm(c, farm.ID)
+	if !ok {
+		return
+	}
+
+	if err := validateStockMovement(createMovementRequest, *farm); err != nil {
+		response := response.BuildFailedResponse("failed to add new stock movement due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	movementModel := models.StockMovement{
+		ItemId:   item.ID,
+		Type:     createMovementRequest.Type,
+		Quantity: createMovementRequest.Quantity,
+		PondId:   createMovementRequest.PondId,
+		UnitCost: createMovementRequest.UnitCost,
+		Note:     createMovementRequest.Note,
+		MovedAt:  time.Now(),
+	}
+	if createMovementRequest.Type == models.StockMovementConsumption {
+		movementModel.Quantity = -createMovementRequest.Quantity
+	}
+	if createMovementRequest.MovedAt != nil {
+		movementModel.MovedAt = *createMovementRequest.MovedAt
+	}
+
+	newMovement, err := handler.StockMovementRepository.Create(movementModel)
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case the stock is not enough
+		case errors.Is(err, repository.ErrInsufficientStock):
+			failedResponse = response.BuildFailedResponse("failed to add new stock movement due to insufficient stock", err.Error())
+			c.AbortWithStatusJSON(http.StatusConflict, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to add new stock movement due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new stock movement instance to database", newMovement)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Stock Movement of Inventory Item
+func (handler *InventoryHandler) GetAllMovement(c *gin.Context) {
+	var getAllRequest validator.GetAllStockMovementRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	item, ok := handler.getFarmItem(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.StockMovementFilter{Type: getAllRequest.Type, PondId: getAllRequest.PondId, From: getAllRequest.From, To: getAllRequest.To}
+	movements, err := handler.StockMovementRepository.GetAllByItem(item.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !movements.CursorMode && movements.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", movements)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to get inventory item from ":itemId" param of the farm of the caller tenant
+func (handler *InventoryHandler) getFarmItem(c *gin.Context) (*models.InventoryItem, bool) {
+	farm, ok := getTenantFarm(c)
+	if !ok {
+		return nil, false
+	}
+	return handler.getItemOfFarm(c, farm.ID)
+}
+
+// Helper to get inventory item from ":itemId" param that belong to the farm.
+// If it does not exist, the failed response is written and false is returned
+func (handler *InventoryHandler) getItemOfFarm(c *gin.Context, farmId uint) (*models.InventoryItem, bool) {
+	itemId, err := helpers.ParseUint(c.Param("itemId"))
+	if err != nil || itemId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return nil, false
+	}
+
+	where := models.InventoryItem{FarmId: farmId}
+	where.ID = itemId
+	item, err := handler.InventoryItemRepository.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return nil, false
+	}
+	return item, true
+}
+
+// Helper to build the response of inventory item with its on-hand balance
+func (handler *InventoryHandler) withBalance(item models.InventoryItem) (dto.InventoryItemResponseDto, error) {
+	balances, err := handler.InventoryItemRepository.GetBalances([]uint{item.ID})
+	if err != nil {
+		return dto.InventoryItemResponseDto{}, err
+	}
+	onHand := balances[item.ID]
+	return dto.InventoryItemResponseDto{InventoryItem: item, OnHand: onHand, LowStock: item.IsLowStock(onHand)}, nil
+}
+
+// Helper to validate stock movement. Purchase and consumption quantity must be positive,
+// only consumption refer to pond and it must be the pond of the farm, only purchase has unit cost
+func validateStockMovement(request validator.CreateStockMovementRequest, farm models.Farm) error {
+	if request.Type != models.StockMovementAdjustment && request.Quantity <= 0 {
+		return errors.New("quantity of purchase and consumption must be positive")
+	}
+	if request.UnitCost != nil && request.Type != models.StockMovementPurchase {
+		return errors.New("only purchase can have unit cost")
+	}
+	if request.PondId == nil {
+		return nil
+	}
+	if request.Type != models.StockMovementConsumption {
+		return errors.New("only consumption can refer to pond")
+	}
+	for _, pond := range farm.Ponds {
+		if pond.ID == *request.PondId {
+			return nil
+		}
+	}
+	return errors.New("pond does not belong to the farm")
+}
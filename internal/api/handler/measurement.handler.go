@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/aggregate"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/alert"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/telemetry"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+var measurementHandler *MeasurementHandler
+
+type MeasurementHandler struct {
+	MeasurementRepository repository.MeasurementRepositoryInterface
+	DeviceRepository      repository.DeviceRepositoryInterface
+	AlertEngine           alert.EngineInterface
+}
+
+type MeasurementHandlerInterface interface {
+	Ingest(c *gin.Context)
+	GetAllMeasurement(c *gin.Context)
+	GetAggregate(c *gin.Context)
+}
+
+// Func to get Measurement Handler instance
+func GetMeasurementHandler() MeasurementHandlerInterface {
+	if measurementHandler == nil {
+		measurementHandler = &MeasurementHandler{
+			MeasurementRepository: repository.GetMeasurementRepository(),
+			DeviceRepository:      repository.GetDeviceRepository(),
+			AlertEngine:           alert.GetAlertEngine(),
+		}
+	}
+	return measurementHandler
+}
+
+// HandlerFunc to Ingest batch of readings sent by device (POST).
+// The body is either JSON or line protocol (text/plain), reading that is already sent is skipped
+func (handler *MeasurementHandler) Ingest(c *gin.Context) {
+	device := c.MustGet("device").(*models.Device)
+
+	// The body is read up to the limit before it is parsed, so large body does not exhaust the memory
+	if maxBodyBytes := config.GetConfig().Ingest.MaxBodyBytes; maxBodyBytes > 0 {
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBodyBytes))
+		if err != nil {
+			response := response.BuildFailedResponse("failed to ingest readings due to too large body", fmt.Sprintf("body must be at most %d bytes", maxBodyBytes))
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, response)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	var measurements []models.Measurement
+	var err error
+	switch c.ContentType() {
+	case gin.MIMEJSON:
+		measurements, err = bindJsonReadings(c)
+	case gin.MIMEPlain:
+		var ingestQuery validator.IngestQueryRequest
+		if err = c.ShouldBindQuery(&ingestQuery); err == nil {
+			measurements, err = telemetry.ParseLineProtocol(c.Request.Body, ingestQuery.Precision)
+		}
+	default:
+		response := response.BuildFailedResponse("failed to ingest readings due to unsupported media type", "content type must be application/json or text/plain")
+		c.AbortWithStatusJSON(http.StatusUnsupportedMediaType, response)
+		return
+	}
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to ingest readings due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if len(measurements) == 0 {
+		response := response.BuildFailedResponse("failed to ingest readings due to bad request", "no reading found")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if maxBatchSize := config.GetConfig().Ingest.MaxBatchSize; maxBatchSize > 0 && len(measurements) > maxBatchSize {
+		response := response.BuildFailedResponse("failed to ingest readings due to too many readings", fmt.Sprintf("at most %d readings can be sent in single request", maxBatchSize))
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, response)
+		return
+	}
+
+	for i := range measurements {
+		measurements[i].PondId = device.PondId
+		measurements[i].DeviceId = device.ID
+	}
+
+	storedMeasurements, err := handler.MeasurementRepository.CreateBatch(measurements)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to ingest readings due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// The readings are already stored, so failure on evaluation or marking the device does not fail the request.
+	// Only the stored readings are evaluated, so resent reading does not count again on its alert
+	if len(storedMeasurements) > 0 {
+		if _, err := handler.AlertEngine.EvaluateMeasurements(storedMeasurements); err != nil {
+			log.Println("failed to evaluate alert of measurements:", err)
+		}
+	}
+	if err := handler.DeviceRepository.UpdateLastSeen(device, time.Now()); err != nil {
+		log.Println("failed to update last seen of device:", err)
+	}
+
+	accepted := int64(len(storedMeasurements))
+	ingestDto := dto.IngestResponseDto{Accepted: accepted, Duplicated: int64(len(measurements)) - accepted}
+	response := response.BuildSuccessResponse("success ingest readings to database", ingestDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Measurement of Pond (by device and time range)
+func (handler *MeasurementHandler) GetAllMeasurement(c *gin.Context) {
+	var getAllRequest validator.GetAllMeasurementRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.MeasurementFilter{DeviceId: getAllRequest.DeviceId, From: getAllRequest.From, To: getAllRequest.To}
+	measurements, err := handler.MeasurementRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !measurements.CursorMode && measurements.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", measurements)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get Aggregate of Measurement of Pond in time buckets, default range is the last 24 hours
+func (handler *MeasurementHandler) GetAggregate(c *gin.Context) {
+	var aggregateRequest validator.AggregateReadingRequest
+	if err := c.ShouldBindQuery(&aggregateRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	to := aggregateRequest.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	from := aggregateRequest.From
+	if from.IsZero() {
+		from = to.Add(-24 * time.Hour)
+	}
+
+	query, err := aggregate.NewQuery(aggregateRequest.Metric, aggregateRequest.Fn, aggregateRequest.Interval, from, to)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	buckets, err := handler.MeasurementRepository.Aggregate(pond.ID, query)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	aggregateDto := dto.AggregateResponseDto{
+		Metric:   query.Parameter,
+		Function: query.Function,
+		Interval: aggregateRequest.Interval,
+		From:     query.From,
+		To:       query.To,
+		Buckets:  buckets,
+	}
+	response := response.BuildSuccessResponse("success to fetch data", aggregateDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to bind JSON readings into measurements, each of them is validated like line protocol reading
+func bindJsonReadings(c *gin.Context) ([]models.Measurement, error) {
+	var ingestRequest validator.IngestRequest
+	if err := c.ShouldBindJSON(&ingestRequest); err != nil {
+		return nil, err
+	}
+
+	measurements := make([]models.Measurement, 0, len(ingestRequest.Readings))
+	for i, reading := range ingestRequest.Readings {
+		measurement := reading.ToMeasurement()
+		if err := telemetry.Validate(measurement); err != nil {
+			return nil, fmt.Errorf("reading %d: %w", i, err)
+		}
+		measurements = append(measurements, measurement)
+	}
+	return measurements, nil
+}
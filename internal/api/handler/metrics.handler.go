@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"database/sql"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/metrics"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+var metricsHandler *MetricsHandler
+
+type MetricsHandler struct {
+	Registry       *metrics.Registry
+	FarmRepository repository.FarmRepositoryInterface
+	PondRepository repository.PondRepositoryInterface
+}
+
+type MetricsHandlerInterface interface {
+	GetMetrics(c *gin.Context)
+}
+
+// Func to get Metrics Handler instance, the database and domain metrics are registered on creation
+func GetMetricsHandler() MetricsHandlerInterface {
+	if metricsHandler == nil {
+		metricsHandler = &MetricsHandler{
+			Registry:       metrics.GetRegistry(),
+			FarmRepository: repository.GetFarmRepository(),
+			PondRepository: repository.GetPondRepository(),
+		}
+		metricsHandler.Registry.Register(
+			metrics.NewDBStatsCollector(databaseStats),
+			metrics.CollectorFunc(metricsHandler.collectDomain),
+		)
+	}
+	return metricsHandler
+}
+
+// HandlerFunc to Get Metrics in Prometheus text exposition format
+func (handler *MetricsHandler) GetMetrics(c *gin.Context) {
+	var buffer bytes.Buffer
+	if err := handler.Registry.WriteText(&buffer); err != nil {
+		response := response.BuildFailedResponse("failed to collect metrics due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.Data(http.StatusOK, metrics.ContentType, buffer.Bytes())
+}
+
+// Helper to collect count of farms and ponds (per status) on every scrape.
+// The family is skipped when the count fails, so the scrape still succeed
+func (handler *MetricsHandler) collectDomain() []metrics.Family {
+	var families []metrics.Family
+
+	if farmCount, err := handler.FarmRepository.Count(); err != nil {
+		log.Println("failed to count farms for metrics:", err)
+	} else {
+		families = append(families, metrics.Family{
+			Name:    "farms",
+			Help:    "Number of farms.",
+			Type:    metrics.TypeGauge,
+			Samples: []metrics.Sample{{Name: "farms", Value: float64(farmCount)}},
+		})
+	}
+
+	if pondCounts, err := handler.PondRepository.CountByStatus(); err != nil {
+		log.Println("failed to count ponds for metrics:", err)
+	} else {
+		// Every status is exposed, so the series does not disappear when it has no pond
+		for _, status := range []string{models.PondStatusActive, models.PondStatusDrying, models.PondStatusMaintenance} {
+			if _, ok := pondCounts[status]; !ok {
+				pondCounts[status] = 0
+			}
+		}
+		family := metrics.Family{Name: "ponds", Help: "Number of ponds by status.", Type: metrics.TypeGauge}
+		statuses := make([]string, 0, len(pondCounts))
+		for status := range pondCounts {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			family.Samples = append(family.Samples, metrics.Sample{
+				Name:   "ponds",
+				Labels: []metrics.Label{{Name: "status", Value: status}},
+				Value:  float64(pondCounts[status]),
+			})
+		}
+		families = append(families, family)
+	}
+	return families
+}
+
+// Helper to get the connection pool statistics of database
+func databaseStats() (sql.DBStats, error) {
+	sqlDB, err := db.GetDB().DB()
+	if err != nil {
+		return sql.DBStats{}, err
+	}
+	return sqlDB.Stats(), nil
+}
@@ -0,0 +1,367 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/mortality"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var mortalityLogHandler *MortalityLogHandler
+
+// Default rolling window and threshold of mortality spike
+const (
+	defaultSpikeWindowHours = 24
+	defaultSpikeThreshold   = 1
+)
+
+type MortalityLogHandler struct {
+	MortalityLogRepository repository.MortalityLogRepositoryInterface
+	CycleRepository        repository.CycleRepositoryInterface
+	HarvestRepository      repository.HarvestRepositoryInterface
+	AlertRepository        repository.AlertRepositoryInterface
+}
+
+type MortalityLogHandlerInterface interface {
+	CreateMortality(c *gin.Context)
+	GetAllMortality(c *gin.Context)
+	DeleteMortality(c *gin.Context)
+	GetSurvival(c *gin.Context)
+	GetSpikes(c *gin.Context)
+}
+
+// Func to get Mortality Log Handler instance
+func GetMortalityLogHandler() MortalityLogHandlerInterface {
+	if mortalityLogHandler == nil {
+		mortalityLogHandler = &MortalityLogHandler{
+			MortalityLogRepository: repository.GetMortalityLogRepository(),
+			CycleRepository:        repository.GetCycleRepository(),
+			HarvestRepository:      repository.GetHarvestRepository(),
+			AlertRepository:        repository.GetAlertRepository(),
+		}
+	}
+	return mortalityLogHandler
+}
+
+// HandlerFunc to Create Mortality Log of the active Cycle of Pond (POST)
+func (handler *MortalityLogHandler) CreateMortality(c *gin.Context) {
+	var createMortalityRequest validator.CreateMortalityLogRequest
+	err := c.ShouldBind(&createMortalityRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new mortality log due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	// Dead stock is recorded on the active cycle
+	activeCycle, err := handler.CycleRepository.GetActiveByPond(pond.ID)
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case pond is not stocked
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to add new mortality log due to no active cycle", "pond does not have active cycle")
+			c.AbortWithStatusJSON(http.StatusConflict, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to add new mortality log due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	mortalityModel := models.MortalityLog{
+		PondId:     pond.ID,
+		CycleId:    activeCycle.ID,
+		Count:      createMortalityRequest.Count,
+		Cause:      createMortalityRequest.Cause,
+		RecordedAt: time.Now(),
+	}
+	if createMortalityRequest.RecordedAt != nil {
+		mortalityModel.RecordedAt = *createMortalityRequest.RecordedAt
+	}
+
+	if mortalityModel.RecordedAt.Before(activeCycle.StockingDate) {
+		response := response.BuildFailedResponse("failed to add new mortality log due to bad request", "recorded_at must not be before stocking date of the cycle")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	// Dead stock must not exceed the stock that is still in the pond
+	remaining, err := remainingStock(handler.MortalityLogRepository, handler.HarvestRepository, activeCycle)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new mortality log due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	if mortalityModel.Count > remaining {
+		response := response.BuildFailedResponse("failed to add new mortality log due to bad request", fmt.Sprintf("count must not exceed the remaining stock of %d", remaining))
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	newMortality, err := handler.MortalityLogRepository.Create(mortalityModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new mortality log due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new mortality log instance to database", newMortality)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to sum the dead and the harvested stock of cycle
+func sumCycleOutflow(mortalityRepo repository.MortalityLogRepositoryInterface, harvestRepo repository.HarvestRepositoryInterface, cycleId uint) (deaths uint, harvested uint, err error) {
+	totals, err := mortalityRepo.SumByCycle(cycleId)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, total := range totals {
+		deaths += total.Count
+	}
+	harvested, err = harvestRepo.SumQuantityByCycle(cycleId)
+	if err != nil {
+		return 0, 0, err
+	}
+	return deaths, harvested, nil
+}
+
+// Helper to compute the stock of cycle that is still in the pond (neither dead nor harvested)
+func remainingStock(mortalityRepo repository.MortalityLogRepositoryInterface, harvestRepo repository.HarvestRepositoryInterface, cycle *models.Cycle) (uint, error) {
+	deaths, harvested, err := sumCycleOutflow(mortalityRepo, harvestRepo, cycle.ID)
+	if err != nil {
+		return 0, err
+	}
+	return helpers.RemainingCount(cycle.InitialCount, deaths+harvested), nil
+}
+
+// HandlerFunc to Get All Mortality Log of Pond (by time range and cycle)
+func (handler *MortalityLogHandler) GetAllMortality(c *gin.Context) {
+	var getAllRequest validator.GetAllMortalityLogRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.MortalityLogFilter{CycleId: getAllRequest.CycleId, From: getAllRequest.From, To: getAllRequest.To}
+	mortalityLogs, err := handler.MortalityLogRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !mortalityLogs.CursorMode && mortalityLogs.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", mortalityLogs)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Delete Mortality Log of Pond
+func (handler *MortalityLogHandler) DeleteMortality(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	mortalityRepo := handler.MortalityLogRepository
+	mortalityId, err := helpers.ParseUint(c.Param("mortalityId"))
+	if err != nil || mortalityId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return
+	}
+
+	// The mortality log must belong to the pond
+	where := models.MortalityLog{PondId: pond.ID}
+	where.ID = mortalityId
+	existedMortality, err := mortalityRepo.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	err = mortalityRepo.Delete(existedMortality)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to delete a mortality log", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// HandlerFunc to Get Survival Rate of Cycle of Pond, the stocked count minus the dead stock
+func (handler *MortalityLogHandler) GetSurvival(c *gin.Context) {
+	var survivalRequest validator.MortalitySurvivalRequest
+	if err := c.ShouldBindQuery(&survivalRequest); err != nil {
+		response := response.BuildFailedResponse("failed to compute survival rate due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	cycle, ok := handler.getReportCycle(c, survivalRequest.CycleId)
+	if !ok {
+		return
+	}
+
+	totals, err := handler.MortalityLogRepository.SumByCycle(cycle.ID)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to compute survival rate due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	deaths, harvested, err := sumCycleOutflow(handler.MortalityLogRepository, handler.HarvestRepository, cycle.ID)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to compute survival rate due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// Harvested stock survived the cycle, it is only no longer in the pond
+	survivalDto := dto.MortalitySurvivalResponseDto{
+		CycleId:      cycle.ID,
+		InitialCount: cycle.InitialCount,
+		Deaths:       deaths,
+		Harvested:    harvested,
+		Alive:        helpers.RemainingCount(cycle.InitialCount, deaths+harvested),
+		ByCause:      totals,
+	}
+	survivalDto.SurvivalRate = helpers.SurvivalRate(survivalDto.Alive+harvested, cycle.InitialCount)
+
+	response := response.BuildSuccessResponse("success to compute survival rate", survivalDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get Mortality Spike of Cycle of Pond over rolling window,
+// together with the water quality alerts of the pond in the same period
+func (handler *MortalityLogHandler) GetSpikes(c *gin.Context) {
+	spikeRequest := validator.MortalitySpikeRequest{WindowHours: defaultSpikeWindowHours, Threshold: defaultSpikeThreshold}
+	if err := c.ShouldBindQuery(&spikeRequest); err != nil {
+		response := response.BuildFailedResponse("failed to detect mortality spike due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	cycle, ok := handler.getReportCycle(c, spikeRequest.CycleId)
+	if !ok {
+		return
+	}
+
+	mortalityLogs, err := handler.MortalityLogRepository.GetAllByCycle(cycle.ID)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to detect mortality spike due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	window := time.Duration(spikeRequest.WindowHours) * time.Hour
+	spikes := mortality.DetectSpikes(*mortalityLogs, cycle.InitialCount, window, spikeRequest.Threshold)
+
+	spikeDto := dto.MortalitySpikeResponseDto{
+		CycleId:     cycle.ID,
+		WindowHours: spikeRequest.WindowHours,
+		Threshold:   spikeRequest.Threshold,
+		Spikes:      make([]dto.MortalitySpikeDto, 0, len(spikes)),
+	}
+	alertRepo := handler.AlertRepository.WithTenant(helpers.GetTenant(c))
+	for _, spike := range spikes {
+		// Water quality may drop before the stock die, so the alerts are looked up from one window earlier
+		alerts, err := alertRepo.GetAllByPondInRange(cycle.PondId, spike.Start.Add(-window), spike.End)
+		if err != nil {
+			response := response.BuildFailedResponse("failed to detect mortality spike due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+		spikeDto.Spikes = append(spikeDto.Spikes, dto.MortalitySpikeDto{Spike: spike, Alerts: *alerts})
+	}
+
+	response := response.BuildSuccessResponse("success to detect mortality spike", spikeDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to get the cycle of pond for the report, the active cycle when cycleId is zero.
+// If it does not exist, the failed response is written and false is returned
+func (handler *MortalityLogHandler) getReportCycle(c *gin.Context, cycleId uint) (*models.Cycle, bool) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return nil, false
+	}
+
+	var cycle *models.Cycle
+	var err error
+	if cycleId == 0 {
+		cycle, err = handler.CycleRepository.GetActiveByPond(pond.ID)
+	} else {
+		where := models.Cycle{PondId: pond.ID}
+		where.ID = cycleId
+		cycle, err = handler.CycleRepository.GetByModel(where)
+	}
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no cycle found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return nil, false
+	}
+	return cycle, true
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var organizationHandler *OrganizationHandler
+
+type OrganizationHandler struct {
+	OrganizationRepository repository.OrganizationRepositoryInterface
+	UserRepository         repository.UserRepositoryInterface
+}
+
+type OrganizationHandlerInterface interface {
+	CreateOrganization(c *gin.Context)
+	GetAllOrganization(c *gin.Context)
+	AddMember(c *gin.Context)
+}
+
+// Func to get Organization Handler instance
+func GetOrganizationHandler() OrganizationHandlerInterface {
+	if organizationHandler == nil {
+		organizationHandler = &OrganizationHandler{
+			OrganizationRepository: repository.GetOrganizationRepository(),
+			UserRepository:         repository.GetUserRepository(),
+		}
+	}
+	return organizationHandler
+}
+
+// HandlerFunc to Create Organization (POST).
+// The caller become the first member of the organization
+func (handler *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var createOrganizationRequest validator.CreateOrganizationRequest
+	err := c.ShouldBind(&createOrganizationRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new organization due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	caller, err := handler.UserRepository.GetById(c.GetString("user_id"))
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new organization due to unknown user", err.Error())
+		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		return
+	}
+
+	organizationRepo := handler.OrganizationRepository
+	newOrganization, err := organizationRepo.Create(models.Organization{
+		Name: createOrganizationRequest.Name,
+	})
+	if err == nil {
+		err = organizationRepo.AddMember(&newOrganization, caller)
+	}
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new organization due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new organization instance to database", newOrganization)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Organization of the caller
+func (handler *OrganizationHandler) GetAllOrganization(c *gin.Context) {
+	userId, _ := helpers.ParseUint(c.GetString("user_id"))
+	organizations, err := handler.OrganizationRepository.GetAllByUser(userId)
+
+	// Internal server error
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// Error when no record found
+	if len(*organizations) == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", organizations)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Add Member to Organization (POST).
+// Only member of the organization can add new member
+func (handler *OrganizationHandler) AddMember(c *gin.Context) {
+	var addMemberRequest validator.AddMemberRequest
+	err := c.ShouldBind(&addMemberRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add member due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	organizationId, _ := helpers.ParseUint(c.Param("organizationId"))
+	if !helpers.ContainsUint(helpers.GetTenant(c), organizationId) {
+		response := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	organization, err := handler.OrganizationRepository.GetById(c.Param("organizationId"))
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	user, err := handler.UserRepository.GetById(fmt.Sprint(addMemberRequest.UserId))
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to add member due to no user found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to add member", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	if err := handler.OrganizationRepository.AddMember(organization, user); err != nil {
+		response := response.BuildFailedResponse("failed to add member due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
 	"github.com/adiatma85/golang-rest-template-api/pkg/response"
 	"github.com/gin-gonic/gin"
 	"github.com/mashingan/smapping"
@@ -18,7 +21,12 @@ import (
 var pondHandler *PondHandler
 
 type PondHandler struct {
-	PondRepository repository.PondRepositoryInterface
+	PondRepository         repository.PondRepositoryInterface
+	CycleRepository        repository.CycleRepositoryInterface
+	GrowthSampleRepository repository.GrowthSampleRepositoryInterface
+	MortalityLogRepository repository.MortalityLogRepositoryInterface
+	HarvestRepository      repository.HarvestRepositoryInterface
+	TreatmentLogRepository repository.TreatmentLogRepositoryInterface
 }
 
 type PondHandlerInterface interface {
@@ -33,7 +41,12 @@ type PondHandlerInterface interface {
 func GetPondHandler() PondHandlerInterface {
 	if pondHandler == nil {
 		pondHandler = &PondHandler{
-			PondRepository: repository.GetPondRepository(),
+			PondRepository:         repository.GetPondRepository(),
+			CycleRepository:        repository.GetCycleRepository(),
+			GrowthSampleRepository: repository.GetGrowthSampleRepository(),
+			MortalityLogRepository: repository.GetMortalityLogRepository(),
+			HarvestRepository:      repository.GetHarvestRepository(),
+			TreatmentLogRepository: repository.GetTreatmentLogRepository(),
 		}
 	}
 	return pondHandler
@@ -51,14 +64,38 @@ func (handler *PondHandler) CreatePond(c *gin.Context) {
 		return
 	}
 
-	pondRepo := handler.PondRepository
+	if err := createPondRequest.Outline.Validate(); err != nil {
+		response := response.BuildFailedResponse("failed to add new pond due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pondRepo := handler.PondRepository.WithTenant(helpers.GetTenant(c))
 	pondModel := &models.Pond{}
 
 	// smapping the struct
 	smapping.FillStruct(pondModel, smapping.MapFields(&createPondRequest))
+	if pondModel.Type == "" {
+		pondModel.Type = models.PondTypeEarthen
+	}
+	if pondModel.Status == "" {
+		pondModel.Status = models.PondStatusActive
+	}
 
-	// Check if any duplicate is already exist
-	if existedPond, _ := pondRepo.GetByModel(*pondModel); existedPond != nil {
+	if !ensureStockingCountFit(c, *pondModel, createPondRequest.StockingCount, "failed to add new pond due to bad request") {
+		return
+	}
+
+	// The farm must belong to organization of the caller
+	farmRepo := repository.GetFarmRepository().WithTenant(helpers.GetTenant(c))
+	if _, err := farmRepo.GetById(fmt.Sprint(pondModel.FarmId)); err != nil {
+		response := response.BuildFailedResponse("failed to add new pond due to no farm found", err.Error())
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Check if any duplicate (same name in the farm) is already exist
+	if existedPond, _ := pondRepo.GetByModel(models.Pond{Name: pondModel.Name, FarmId: pondModel.FarmId}); existedPond != nil {
 		// If exist, return response with "conflict"
 		response := response.BuildFailedResponse("failed to add new farm due to duplicate resource", "duplicate entry")
 		c.AbortWithStatusJSON(http.StatusConflict, response)
@@ -70,7 +107,6 @@ func (handler *PondHandler) CreatePond(c *gin.Context) {
 		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
 		return
 	} else {
-		farmRepo := repository.GetFarmRepository()
 		pondFarm, _ := farmRepo.GetById(fmt.Sprint(newPond.FarmId))
 		newPond.Farm = *pondFarm
 
@@ -84,19 +120,34 @@ func (handler *PondHandler) CreatePond(c *gin.Context) {
 
 // HandlerFunc to Get All
 func (handler *PondHandler) GetAllPond(c *gin.Context) {
-	pondRepo := handler.PondRepository
+	var getAllRequest validator.GetAllPondRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
 
-	ponds, err := pondRepo.GetAll()
+	pondRepo := handler.PondRepository.WithTenant(helpers.GetTenant(c))
+
+	ponds, err := pondRepo.GetAllPaginated(getAllRequest.ToPagination(), repository.PondFilter{Name: getAllRequest.Name, FarmId: getAllRequest.FarmId, Type: getAllRequest.Type, Status: getAllRequest.Status})
 
-	// Internal server error
 	if err != nil {
-		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
 		return
 	}
 
-	// Error when no record found
-	if len(*ponds) == 0 {
+	// Error when no record found (cursor mode does not count the rows)
+	if !ponds.CursorMode && ponds.TotalRows == 0 {
 		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
 		c.AbortWithStatusJSON(http.StatusNotFound, response)
 		return
@@ -109,7 +160,7 @@ func (handler *PondHandler) GetAllPond(c *gin.Context) {
 
 // HandlerFunc to Get By Id
 func (handler *PondHandler) GetById(c *gin.Context) {
-	pondRepo := handler.PondRepository
+	pondRepo := handler.PondRepository.WithTenant(helpers.GetTenant(c))
 
 	pond, err := pondRepo.GetById(c.Param("pondId"))
 
@@ -130,6 +181,15 @@ func (handler *PondHandler) GetById(c *gin.Context) {
 
 	pondDto := dto.PondResponseDto{}
 	smapping.FillStruct(&pondDto, smapping.MapFields(pond))
+
+	// Growth of the active cycle, estimated from the latest sample
+	pondDto.Growth, err = handler.getGrowth(pond.ID)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
 	response := response.BuildSuccessResponse("success to fetch data", pondDto)
 	c.JSON(http.StatusOK, response)
 }
@@ -145,11 +205,36 @@ func (handler *PondHandler) Update(c *gin.Context) {
 		return
 	}
 
-	pondRepo := handler.PondRepository
+	if err := updatePondRequest.Outline.Validate(); err != nil {
+		response := response.BuildFailedResponse("failed to update pond due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pondRepo := handler.PondRepository.WithTenant(helpers.GetTenant(c))
+	farmRepo := repository.GetFarmRepository().WithTenant(helpers.GetTenant(c))
+
+	// The farm (if specified) must belong to organization of the caller
+	if updatePondRequest.FarmId != 0 {
+		if _, err := farmRepo.GetById(fmt.Sprint(updatePondRequest.FarmId)); err != nil {
+			response := response.BuildFailedResponse("failed to update pond due to no farm found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+	}
 
 	if updatePondRequest.ID == 0 {
 		pondModel := &models.Pond{}
 		smapping.FillStruct(pondModel, smapping.MapFields(&updatePondRequest))
+		if pondModel.Type == "" {
+			pondModel.Type = models.PondTypeEarthen
+		}
+		if pondModel.Status == "" {
+			pondModel.Status = models.PondStatusActive
+		}
+		if !ensureStockingCountFit(c, *pondModel, updatePondRequest.StockingCount, "failed to add new pond due to bad request") {
+			return
+		}
 
 		// Check whether there is error when creating
 		// Need new "small functional" so it does not duplicate
@@ -158,7 +243,6 @@ func (handler *PondHandler) Update(c *gin.Context) {
 			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
 			return
 		} else {
-			farmRepo := repository.GetFarmRepository()
 			pondFarm, _ := farmRepo.GetById(fmt.Sprint(newPond.FarmId))
 			newPond.Farm = *pondFarm
 
@@ -172,14 +256,62 @@ func (handler *PondHandler) Update(c *gin.Context) {
 		// Specified, so update it
 		existedPond, err := pondRepo.GetById(fmt.Sprint(updatePondRequest.ID))
 
-		if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-			response := response.BuildFailedResponse("failed to fetch data due to no record found with specified id", err.Error())
-			c.AbortWithStatusJSON(http.StatusNotFound, response)
+		if err != nil {
+			var failedResponse response.Response
+			switch {
+			// Case error not found
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found with specified id", err.Error())
+				c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+			// Case error on internal server error
+			default:
+				failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+				c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+			}
 			return
 		}
 
+		// Pond is not renamed while a treatment is inside its withdrawal period
+		if updatePondRequest.Name != "" && updatePondRequest.Name != existedPond.Name {
+			if !ensureOutsideWithdrawal(c, handler.TreatmentLogRepository, existedPond.ID, time.Now(), "rename pond") {
+				return
+			}
+		}
+
+		// The stock of active cycle must still fit when the dimension, type or stocking count is changed.
+		// Zero value is not updated, so the density is checked against the merged attributes
+		var activeCycle *models.Cycle
+		mergedPond := mergePondAttributes(*existedPond, updatePondRequest)
+		if mergedPond.StockingDensityChanged(*existedPond) || updatePondRequest.StockingCount != nil {
+			activeCycle, err = handler.CycleRepository.GetActiveByPond(existedPond.ID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				response := response.BuildFailedResponse("failed to update pond due to internal server error", err.Error())
+				c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+				return
+			}
+			// Stocking count is the initial count of the active cycle
+			if activeCycle == nil && updatePondRequest.StockingCount != nil {
+				response := response.BuildFailedResponse("failed to update pond due to no active cycle", "stocking count is recorded on the active cycle, pond does not have active cycle")
+				c.AbortWithStatusJSON(http.StatusConflict, response)
+				return
+			}
+			if activeCycle != nil {
+				if updatePondRequest.StockingCount != nil {
+					activeCycle.InitialCount = *updatePondRequest.StockingCount
+				}
+				if err := validateStockingDensity(mergedPond, activeCycle.InitialCount); err != nil {
+					response := response.BuildFailedResponse("failed to update pond due to bad request", err.Error())
+					c.AbortWithStatusJSON(http.StatusBadRequest, response)
+					return
+				}
+			}
+		}
+
 		smapping.FillStruct(existedPond, smapping.MapFields(&updatePondRequest))
 		err = pondRepo.Update(existedPond)
+		if err == nil && activeCycle != nil && updatePondRequest.StockingCount != nil {
+			err = handler.CycleRepository.Update(activeCycle)
+		}
 
 		if err != nil {
 			response := response.BuildFailedResponse("failed to update a pond", err.Error())
@@ -193,13 +325,27 @@ func (handler *PondHandler) Update(c *gin.Context) {
 
 // HandlerFunc to Delete
 func (handler *PondHandler) Delete(c *gin.Context) {
-	pondRepo := handler.PondRepository
+	pondRepo := handler.PondRepository.WithTenant(helpers.GetTenant(c))
 	existedPond, err := pondRepo.GetById(c.Param("pondId"))
 
 	// If error
-	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
-		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
-		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Pond is not deleted while a treatment is inside its withdrawal period
+	if !ensureOutsideWithdrawal(c, handler.TreatmentLogRepository, existedPond.ID, time.Now(), "delete pond") {
 		return
 	}
 
@@ -212,3 +358,138 @@ func (handler *PondHandler) Delete(c *gin.Context) {
 	}
 	c.JSON(http.StatusNoContent, nil)
 }
+
+// Helper to get pond from ":pondId" param that belong to the tenant of the caller.
+// If it does not exist, the failed response is written and false is returned
+func getTenantPond(c *gin.Context) (*models.Pond, bool) {
+	pondRepo := repository.GetPondRepository().WithTenant(helpers.GetTenant(c))
+	pond, err := pondRepo.GetById(c.Param("pondId"))
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch pond due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch pond", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return nil, false
+	}
+	return pond, true
+}
+
+// Helper to check the stocking count of new pond against the maximum density of the pond type.
+// If it does not fit, the bad request response is written and false is returned
+func ensureStockingCountFit(c *gin.Context, pond models.Pond, stockingCount *uint, message string) bool {
+	if stockingCount == nil {
+		return true
+	}
+	if err := validateStockingDensity(pond, *stockingCount); err != nil {
+		response := response.BuildFailedResponse(message, err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return false
+	}
+	return true
+}
+
+// Helper to check the count stocked in the pond against the maximum density of the pond type.
+// It is not checked when the dimension of the pond is unknown
+func validateStockingDensity(pond models.Pond, count uint) error {
+	maxDensity := maxStockingDensity(config.GetConfig().Stocking, pond.Type)
+	density, ok := pond.StockingDensity(count)
+	if !ok || maxDensity <= 0 || density <= maxDensity {
+		return nil
+	}
+
+	unit := "m²"
+	if pond.Type == models.PondTypeTank || pond.Type == models.PondTypeRas {
+		unit = "m³"
+	}
+	return fmt.Errorf("stocking density %.2f per %s exceed the maximum %.2f of %s pond", density, unit, maxDensity, pond.Type)
+}
+
+// Helper to get the configured maximum stocking density of pond type, zero means no limit
+func maxStockingDensity(configuration config.StockingConfiguration, pondType string) float64 {
+	switch pondType {
+	case models.PondTypeEarthen:
+		return configuration.MaxDensityEarthen
+	case models.PondTypeLined:
+		return configuration.MaxDensityLined
+	case models.PondTypeTank:
+		return configuration.MaxDensityTank
+	case models.PondTypeRas:
+		return configuration.MaxDensityRas
+	}
+	return 0
+}
+
+// Helper to apply the non zero attributes of update request into the pond
+func mergePondAttributes(pond models.Pond, request validator.UpdatePondRequest) models.Pond {
+	if request.Area != 0 {
+		pond.Area = request.Area
+	}
+	if request.Depth != 0 {
+		pond.Depth = request.Depth
+	}
+	if request.Volume != 0 {
+		pond.Volume = request.Volume
+	}
+	if request.Type != "" {
+		pond.Type = request.Type
+	}
+	return pond
+}
+
+// Helper to estimate the growth of the active cycle of pond from its latest sample.
+// Without estimated count on the sample, the stock in the pond is the stocked count minus the dead
+// and the harvested stock. Nil is returned when the pond does not have active cycle
+func (handler *PondHandler) getGrowth(pondId uint) (*dto.PondGrowthDto, error) {
+	activeCycle, err := handler.CycleRepository.GetActiveByPond(pondId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	growth := dto.PondGrowthDto{
+		CycleId:              activeCycle.ID,
+		Species:              activeCycle.Species,
+		StockingDate:         activeCycle.StockingDate,
+		InitialCount:         activeCycle.InitialCount,
+		InitialAverageWeight: activeCycle.InitialAverageWeight,
+		DaysOfCulture:        helpers.DaysOfCulture(activeCycle.StockingDate, time.Now()),
+		AverageBodyWeight:    activeCycle.InitialAverageWeight,
+	}
+
+	deaths, harvested, err := sumCycleOutflow(handler.MortalityLogRepository, handler.HarvestRepository, activeCycle.ID)
+	if err != nil {
+		return nil, err
+	}
+	growth.EstimatedCount = helpers.RemainingCount(activeCycle.InitialCount, deaths+harvested)
+
+	latestSample, err := handler.GrowthSampleRepository.GetLatestByCycle(activeCycle.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if latestSample != nil {
+		growth.SampledAt = &latestSample.SampledAt
+		growth.AverageBodyWeight = latestSample.AverageBodyWeight()
+		days := helpers.DaysOfCulture(activeCycle.StockingDate, latestSample.SampledAt)
+		if rate, ok := helpers.DailyGrowthRate(activeCycle.InitialAverageWeight, growth.AverageBodyWeight, days); ok {
+			growth.DailyGrowthRate = &rate
+		}
+		if latestSample.EstimatedCount != nil {
+			growth.EstimatedCount = *latestSample.EstimatedCount
+		}
+	}
+
+	// Harvested stock survived the cycle
+	growth.SurvivalRate = helpers.SurvivalRate(growth.EstimatedCount+harvested, growth.InitialCount)
+	growth.Biomass = helpers.Biomass(growth.AverageBodyWeight, growth.EstimatedCount)
+	return &growth, nil
+}
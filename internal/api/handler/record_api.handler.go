@@ -1,9 +1,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/traffic"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
 	"github.com/adiatma85/golang-rest-template-api/pkg/response"
 	"github.com/gin-gonic/gin"
 )
@@ -28,13 +33,55 @@ func GetRecordApiHandler() RecordApiHandlerInterface {
 	return recordApiHandler
 }
 
-// HandlerFunc to Get All
+// HandlerFunc to Get All (by time range), or summed per route or status
 func (handler *RecordApiHandler) GetAllRecord(c *gin.Context) {
+	var getAllRequest validator.GetAllRecordApiRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
 	recordApiRepo := handler.RecordApiRepository
+	filter := repository.RecordApiFilter{From: getAllRequest.From, To: getAllRequest.To}
 
-	records, err := recordApiRepo.GetAll()
+	if getAllRequest.GroupBy != "" {
+		handler.getGroupedRecord(c, getAllRequest.GroupBy, filter)
+		return
+	}
+
+	records, err := recordApiRepo.GetAllPaginated(getAllRequest.ToPagination(), filter)
 
-	// Internal server error
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !records.CursorMode && records.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", records)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to response the records summed per route or status, with the latency percentiles
+func (handler *RecordApiHandler) getGroupedRecord(c *gin.Context, groupBy string, filter repository.RecordApiFilter) {
+	groups, err := handler.RecordApiRepository.GetGrouped(groupBy, filter)
 	if err != nil {
 		response := response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
 		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
@@ -42,13 +89,26 @@ func (handler *RecordApiHandler) GetAllRecord(c *gin.Context) {
 	}
 
 	// Error when no record found
-	if len(*records) == 0 {
+	if len(groups) == 0 {
 		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
 		c.AbortWithStatusJSON(http.StatusNotFound, response)
 		return
 	}
 
-	// Response
-	response := response.BuildSuccessResponse("success to fetch data", records)
+	groupDtos := make([]dto.RecordApiGroupResponseDto, 0, len(groups))
+	for _, group := range groups {
+		groupDto := dto.RecordApiGroupResponseDto{
+			RecordApiGroup: group,
+			P50LatencyMs:   traffic.Percentile(group.LatencyHistogram, group.LatencyMaxMs, 50),
+			P90LatencyMs:   traffic.Percentile(group.LatencyHistogram, group.LatencyMaxMs, 90),
+			P99LatencyMs:   traffic.Percentile(group.LatencyHistogram, group.LatencyMaxMs, 99),
+		}
+		if group.Count > 0 {
+			groupDto.AvgLatencyMs = group.LatencySumMs / float64(group.Count)
+		}
+		groupDtos = append(groupDtos, groupDto)
+	}
+
+	response := response.BuildSuccessResponse("success to fetch data", groupDtos)
 	c.JSON(http.StatusOK, response)
 }
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var treatmentLogHandler *TreatmentLogHandler
+
+type TreatmentLogHandler struct {
+	TreatmentLogRepository repository.TreatmentLogRepositoryInterface
+}
+
+type TreatmentLogHandlerInterface interface {
+	CreateTreatment(c *gin.Context)
+	GetAllTreatment(c *gin.Context)
+	DeleteTreatment(c *gin.Context)
+	GetHarvestClearance(c *gin.Context)
+}
+
+// Func to get Treatment Log Handler instance
+func GetTreatmentLogHandler() TreatmentLogHandlerInterface {
+	if treatmentLogHandler == nil {
+		treatmentLogHandler = &TreatmentLogHandler{
+			TreatmentLogRepository: repository.GetTreatmentLogRepository(),
+		}
+	}
+	return treatmentLogHandler
+}
+
+// HandlerFunc to Create Treatment Log of Pond (POST)
+func (handler *TreatmentLogHandler) CreateTreatment(c *gin.Context) {
+	var createTreatmentRequest validator.CreateTreatmentLogRequest
+	err := c.ShouldBind(&createTreatmentRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new treatment log due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	treatmentModel := models.TreatmentLog{
+		PondId:         pond.ID,
+		Product:        createTreatmentRequest.Product,
+		Dose:           createTreatmentRequest.Dose,
+		DoseUnit:       createTreatmentRequest.DoseUnit,
+		WithdrawalDays: createTreatmentRequest.WithdrawalDays,
+		Reason:         createTreatmentRequest.Reason,
+		TreatedAt:      time.Now(),
+	}
+	if createTreatmentRequest.TreatedAt != nil {
+		treatmentModel.TreatedAt = *createTreatmentRequest.TreatedAt
+	}
+
+	newTreatment, err := handler.TreatmentLogRepository.Create(treatmentModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new treatment log due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	response := response.BuildSuccessResponse("success add new treatment log instance to database", newTreatment)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Treatment Log of Pond (by time range)
+func (handler *TreatmentLogHandler) GetAllTreatment(c *gin.Context) {
+	var getAllRequest validator.GetAllTreatmentLogRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.TreatmentLogFilter{From: getAllRequest.From, To: getAllRequest.To}
+	treatmentLogs, err := handler.TreatmentLogRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !treatmentLogs.CursorMode && treatmentLogs.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", treatmentLogs)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Delete Treatment Log of Pond
+func (handler *TreatmentLogHandler) DeleteTreatment(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	treatmentRepo := handler.TreatmentLogRepository
+	treatmentId, err := helpers.ParseUint(c.Param("treatmentId"))
+	if err != nil || treatmentId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return
+	}
+
+	// The treatment log must belong to the pond
+	where := models.TreatmentLog{PondId: pond.ID}
+	where.ID = treatmentId
+	existedTreatment, err := treatmentRepo.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Deleting the treatment would lift the withdrawal period it put on the pond
+	if existedTreatment.IsWithdrawing(time.Now()) {
+		response := response.BuildFailedResponse("failed to delete a treatment log due to withdrawal period", fmt.Sprintf("treatment is inside withdrawal period until %s", existedTreatment.WithdrawalEndsAt().Format(time.RFC3339)))
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return
+	}
+
+	err = treatmentRepo.Delete(existedTreatment)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to delete a treatment log", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// HandlerFunc to Get whether Pond can be harvested, that is no treatment is inside its withdrawal period
+func (handler *TreatmentLogHandler) GetHarvestClearance(c *gin.Context) {
+	var clearanceRequest validator.HarvestClearanceRequest
+	if err := c.ShouldBindQuery(&clearanceRequest); err != nil {
+		response := response.BuildFailedResponse("failed to check harvest clearance due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+	if clearanceRequest.At.IsZero() {
+		clearanceRequest.At = time.Now()
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	activeTreatments, err := handler.TreatmentLogRepository.GetWithdrawingByPond(pond.ID, clearanceRequest.At)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to check harvest clearance due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	clearanceDto := dto.HarvestClearanceResponseDto{
+		PondId:           pond.ID,
+		At:               clearanceRequest.At,
+		Cleared:          len(*activeTreatments) == 0,
+		ActiveTreatments: *activeTreatments,
+	}
+	// The latest ending treatment comes first
+	if !clearanceDto.Cleared {
+		clearedAt := (*activeTreatments)[0].WithdrawalEndsAt()
+		clearanceDto.ClearedAt = &clearedAt
+	}
+
+	response := response.BuildSuccessResponse("success to check harvest clearance", clearanceDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper to reject the action on pond that is inside the withdrawal period of a treatment at the time.
+// If it is, the failed response is written and false is returned
+func ensureOutsideWithdrawal(c *gin.Context, treatmentRepo repository.TreatmentLogRepositoryInterface, pondId uint, at time.Time, action string) bool {
+	activeTreatments, err := treatmentRepo.GetWithdrawingByPond(pondId, at)
+	if err != nil {
+		response := response.BuildFailedResponse(fmt.Sprintf("failed to %s due to internal server error", action), err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return false
+	}
+
+	if len(*activeTreatments) > 0 {
+		clearedAt := (*activeTreatments)[0].WithdrawalEndsAt()
+		response := response.BuildFailedResponse(fmt.Sprintf("failed to %s due to withdrawal period", action), fmt.Sprintf("pond is inside withdrawal period until %s", clearedAt.Format(time.RFC3339)))
+		c.AbortWithStatusJSON(http.StatusConflict, response)
+		return false
+	}
+	return true
+}
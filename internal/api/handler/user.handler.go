@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/mashingan/smapping"
+	"gorm.io/gorm"
+)
+
+var userHandler *UserHandler
+
+type UserHandler struct {
+	UserRepository         repository.UserRepositoryInterface
+	RefreshTokenRepository repository.RefreshTokenRepositoryInterface
+}
+
+type UserHandlerInterface interface {
+	UpdateRole(c *gin.Context)
+}
+
+// Func to get User Handler instance
+func GetUserHandler() UserHandlerInterface {
+	if userHandler == nil {
+		userHandler = &UserHandler{
+			UserRepository:         repository.GetUserRepository(),
+			RefreshTokenRepository: repository.GetRefreshTokenRepository(),
+		}
+	}
+	return userHandler
+}
+
+// HandlerFunc to Update Role of User (PUT)
+func (handler *UserHandler) UpdateRole(c *gin.Context) {
+	var updateRoleRequest validator.UpdateRoleRequest
+	err := c.ShouldBind(&updateRoleRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to update role due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	userRepo := handler.UserRepository
+	existedUser, err := userRepo.GetById(c.Param("userId"))
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	roleChanged := existedUser.Role != updateRoleRequest.Role
+	existedUser.Role = updateRoleRequest.Role
+	if err := userRepo.Update(existedUser); err != nil {
+		response := response.BuildFailedResponse("failed to update role", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// Role is carried by the token, so the user must login again to get the new role.
+	// Access token that is already issued keep the old role until it expires
+	if roleChanged {
+		if err := handler.RefreshTokenRepository.RevokeAllByUser(existedUser.ID); err != nil {
+			response := response.BuildFailedResponse("failed to revoke token of user", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+	}
+
+	userDto := dto.UserResponseDto{}
+	smapping.FillStruct(&userDto, smapping.MapFields(existedUser))
+	response := response.BuildSuccessResponse("success update role of user", userDto)
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/alert"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var waterQualityReadingHandler *WaterQualityReadingHandler
+
+type WaterQualityReadingHandler struct {
+	WaterQualityReadingRepository repository.WaterQualityReadingRepositoryInterface
+	AlertEngine                   alert.EngineInterface
+}
+
+type WaterQualityReadingHandlerInterface interface {
+	CreateReading(c *gin.Context)
+	GetAllReading(c *gin.Context)
+	DeleteReading(c *gin.Context)
+}
+
+// Func to get Water Quality Reading Handler instance
+func GetWaterQualityReadingHandler() WaterQualityReadingHandlerInterface {
+	if waterQualityReadingHandler == nil {
+		waterQualityReadingHandler = &WaterQualityReadingHandler{
+			WaterQualityReadingRepository: repository.GetWaterQualityReadingRepository(),
+			AlertEngine:                   alert.GetAlertEngine(),
+		}
+	}
+	return waterQualityReadingHandler
+}
+
+// HandlerFunc to Create Water Quality Reading of Pond (POST)
+func (handler *WaterQualityReadingHandler) CreateReading(c *gin.Context) {
+	var createReadingRequest validator.CreateWaterQualityReadingRequest
+	err := c.ShouldBind(&createReadingRequest)
+
+	// Bad Request
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new reading due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	if !createReadingRequest.HasMeasurement() {
+		response := response.BuildFailedResponse("failed to add new reading due to bad request", "at least one parameter must be measured")
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	readingModel := models.WaterQualityReading{
+		PondId:          pond.ID,
+		MeasuredAt:      time.Now(),
+		DissolvedOxygen: createReadingRequest.DissolvedOxygen,
+		Ph:              createReadingRequest.Ph,
+		Temperature:     createReadingRequest.Temperature,
+		Salinity:        createReadingRequest.Salinity,
+		Ammonia:         createReadingRequest.Ammonia,
+		Nitrite:         createReadingRequest.Nitrite,
+	}
+	if createReadingRequest.MeasuredAt != nil {
+		readingModel.MeasuredAt = *createReadingRequest.MeasuredAt
+	}
+
+	newReading, err := handler.WaterQualityReadingRepository.Create(readingModel)
+	if err != nil {
+		response := response.BuildFailedResponse("failed to add new reading due to internal server error", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+
+	// The reading is already stored, so failure on evaluation does not fail the request
+	alerts, err := handler.AlertEngine.Evaluate(newReading)
+	if err != nil {
+		log.Println("failed to evaluate alert of reading:", err)
+	}
+
+	readingDto := dto.WaterQualityReadingResponseDto{WaterQualityReading: newReading, Alerts: alerts}
+	response := response.BuildSuccessResponse("success add new reading instance to database", readingDto)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Get All Water Quality Reading of Pond (by time range)
+func (handler *WaterQualityReadingHandler) GetAllReading(c *gin.Context) {
+	var getAllRequest validator.GetAllWaterQualityReadingRequest
+	if err := c.ShouldBindQuery(&getAllRequest); err != nil {
+		response := response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		return
+	}
+
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	filter := repository.WaterQualityReadingFilter{From: getAllRequest.From, To: getAllRequest.To}
+	readings, err := handler.WaterQualityReadingRepository.GetAllByPond(pond.ID, getAllRequest.ToPagination(), filter)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error on sort field that is not allowed or invalid cursor
+		case errors.Is(err, helpers.ErrInvalidSort), errors.Is(err, helpers.ErrInvalidCursor):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to bad request", err.Error())
+			c.AbortWithStatusJSON(http.StatusBadRequest, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	// Error when no record found (cursor mode does not count the rows)
+	if !readings.CursorMode && readings.TotalRows == 0 {
+		response := response.BuildFailedResponse("failed to fetch data due to no data row found", "no record found")
+		c.AbortWithStatusJSON(http.StatusNotFound, response)
+		return
+	}
+
+	// Response
+	response := response.BuildSuccessResponse("success to fetch data", readings)
+	c.JSON(http.StatusOK, response)
+}
+
+// HandlerFunc to Delete Water Quality Reading of Pond
+func (handler *WaterQualityReadingHandler) DeleteReading(c *gin.Context) {
+	pond, ok := getTenantPond(c)
+	if !ok {
+		return
+	}
+
+	readingRepo := handler.WaterQualityReadingRepository
+	readingId, err := helpers.ParseUint(c.Param("readingId"))
+	if err != nil || readingId == 0 {
+		failedResponse := response.BuildFailedResponse("failed to fetch data due to no record found", "record not found")
+		c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		return
+	}
+
+	// The reading must belong to the pond
+	where := models.WaterQualityReading{PondId: pond.ID}
+	where.ID = readingId
+	existedReading, err := readingRepo.GetByModel(where)
+
+	if err != nil {
+		var failedResponse response.Response
+		switch {
+		// Case error not found
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			failedResponse = response.BuildFailedResponse("failed to fetch data due to no record found", err.Error())
+			c.AbortWithStatusJSON(http.StatusNotFound, failedResponse)
+		// Case error on internal server error
+		default:
+			failedResponse = response.BuildFailedResponse("failed to fetch data", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, failedResponse)
+		}
+		return
+	}
+
+	err = readingRepo.Delete(existedReading)
+
+	if err != nil {
+		response := response.BuildFailedResponse("failed to delete a reading", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		return
+	}
+	c.JSON(http.StatusNoContent, nil)
+}
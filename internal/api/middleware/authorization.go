@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// Permission that is required by a route
+type Permission string
+
+// Available permissions
+const (
+	PermissionFarmRead   Permission = "farm:read"
+	PermissionFarmWrite  Permission = "farm:write"
+	PermissionFarmDelete Permission = "farm:delete"
+	PermissionPondRead   Permission = "pond:read"
+	PermissionPondWrite  Permission = "pond:write"
+	PermissionPondDelete Permission = "pond:delete"
+	PermissionUserManage Permission = "user:manage"
+
+	PermissionOrganizationManage Permission = "organization:manage"
+
+	PermissionAlertWrite     Permission = "alert:write"
+	PermissionThresholdWrite Permission = "threshold:write"
+	PermissionDeviceManage   Permission = "device:manage"
+	PermissionInventoryWrite Permission = "inventory:write"
+	PermissionTrafficRead    Permission = "traffic:read"
+)
+
+// Mapping of role to its granted permissions
+var rolePermissions = map[string][]Permission{
+	models.RoleAdmin: {
+		PermissionFarmRead, PermissionFarmWrite, PermissionFarmDelete,
+		PermissionPondRead, PermissionPondWrite, PermissionPondDelete,
+		PermissionUserManage, PermissionOrganizationManage,
+		PermissionAlertWrite, PermissionThresholdWrite, PermissionDeviceManage,
+		PermissionInventoryWrite, PermissionTrafficRead,
+	},
+	models.RoleFarmManager: {
+		PermissionFarmRead, PermissionFarmWrite, PermissionFarmDelete,
+		PermissionPondRead, PermissionPondWrite, PermissionPondDelete,
+		PermissionOrganizationManage,
+		PermissionAlertWrite, PermissionThresholdWrite, PermissionDeviceManage,
+		PermissionInventoryWrite,
+	},
+	models.RoleOperator: {
+		PermissionFarmRead,
+		PermissionPondRead, PermissionPondWrite,
+		PermissionAlertWrite, PermissionInventoryWrite,
+	},
+	models.RoleViewer: {
+		PermissionFarmRead,
+		PermissionPondRead,
+	},
+}
+
+// Func to check whether role is granted the permission
+func HasPermission(role string, permission Permission) bool {
+	for _, granted := range rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Func to authorizing the role (set by AuthJWT) against route permission.
+// Must be used after AuthJWT
+func Authorize(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !HasPermission(c.GetString("role"), permission) {
+			response := response.BuildFailedResponse("permission denied", "required permission "+string(permission))
+			c.AbortWithStatusJSON(http.StatusForbidden, response)
+			return
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// Header that carry the api key of the device
+const DeviceKeyHeader = "X-Device-Key"
+
+// Func to authenticate device by its api key
+func AuthDevice() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		apiKey := c.GetHeader(DeviceKeyHeader)
+		if apiKey == "" {
+			response := response.BuildFailedResponse("no device key provided", nil)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		apiKeyHash := crypto.GetDeviceKeyCryptoHelper().HashDeviceKey(apiKey)
+		device, err := repository.GetDeviceRepository().GetByApiKeyHash(apiKeyHash)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				response := response.BuildFailedResponse("device key is not valid", "device not found")
+				c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+				return
+			}
+			response := response.BuildFailedResponse("failed to authenticate device", err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+			return
+		}
+
+		// Set the device so the next handler know which device and pond is sending
+		c.Set("device", device)
+	}
+}
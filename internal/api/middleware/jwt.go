@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
 	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
 	"github.com/adiatma85/golang-rest-template-api/pkg/response"
 	"github.com/gin-gonic/gin"
@@ -19,13 +20,41 @@ func AuthJWT() gin.HandlerFunc {
 			return
 		}
 
-		token := strings.Split(authHeader, " ")[1]
+		// Expected format is "Bearer <token>"
+		splittedHeader := strings.SplitN(authHeader, " ", 2)
+		if len(splittedHeader) != 2 || splittedHeader[1] == "" {
+			response := response.BuildFailedResponse("token is not valid", "malformed authorization header")
+			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+			return
+		}
+
+		token := splittedHeader[1]
 		jwtHelper := crypto.GetJWTCrypto()
-		isValid, err := jwtHelper.ValidateToken(token)
-		if !isValid {
+		claims, err := jwtHelper.ParseToken(token)
+		if err != nil {
 			response := response.BuildFailedResponse("token is not valid", err.Error())
 			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 			return
 		}
+
+		// Reject token that is revoked before its expiry (e.g. logout), fail closed when it can not be checked
+		isRevoked, err := repository.GetRevokedTokenRepository().IsRevoked(claims.Id)
+		if err != nil {
+			response := response.BuildFailedResponse("failed to check token due to internal server error", err.Error())
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, response)
+			return
+		}
+		if isRevoked {
+			response := response.BuildFailedResponse("token is not valid", "token has been revoked")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		// Set the user id so the next handler know who is calling
+		c.Set("user_id", claims.UserID)
+		c.Set("role", claims.Role)
+		c.Set("organization_ids", claims.OrganizationIds)
+		c.Set("jti", claims.Id)
+		c.Set("token_expires_at", claims.ExpiresAt)
 	}
 }
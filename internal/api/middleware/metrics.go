@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/metrics"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	httpRequestsTotal       = metrics.NewCounterVec("http_requests_total", "Total number of HTTP requests by method, route and status.", "method", "route", "status")
+	httpRequestDuration     = metrics.NewHistogramVec("http_request_duration_seconds", "Latency of HTTP requests by method, route and status.", metrics.DefaultBuckets, "method", "route", "status")
+	httpRequestsInFlight    = metrics.NewGaugeVec("http_requests_in_flight", "Number of HTTP requests being served.")
+	registerHttpMetricsOnce sync.Once
+)
+
+// Middleware to count the requests and their latency for the metrics endpoint
+func Metrics() gin.HandlerFunc {
+	// The router can be set up more than once (e.g. in tests), the metrics are registered once
+	registerHttpMetricsOnce.Do(func() {
+		metrics.GetRegistry().Register(httpRequestsTotal, httpRequestDuration, httpRequestsInFlight)
+	})
+
+	return func(c *gin.Context) {
+		startedAt := time.Now()
+		httpRequestsInFlight.Inc()
+		defer httpRequestsInFlight.Dec()
+
+		c.Next()
+
+		method := models.NormalizeMethod(c.Request.Method)
+		route := routeTemplate(c)
+		status := strconv.Itoa(c.Writer.Status())
+		httpRequestsTotal.Inc(method, route, status)
+		httpRequestDuration.Observe(time.Since(startedAt).Seconds(), method, route, status)
+	}
+}
+
+// Middleware to authorize the scraper of metrics by the token.
+// The metrics expose totals across every organization, so the endpoint is not found when the token is not set
+func AuthMetrics(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			response := response.BuildFailedResponse("failed to fetch data due to no record found", "metrics is disabled")
+			c.AbortWithStatusJSON(http.StatusNotFound, response)
+			return
+		}
+
+		bearerToken := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(bearerToken), []byte(token)) != 1 {
+			response := response.BuildFailedResponse("token is not valid", "metrics token is not valid")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+	}
+}
+
+// Helper to get the route template of the request (e.g. /api/v1/farm/:farmId),
+// so the path params do not make a series per resource
+func routeTemplate(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return models.RecordApiUnmatchedRoute
+}
@@ -0,0 +1,15 @@
+package ingest
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/api/handler"
+	"github.com/adiatma85/golang-rest-template-api/internal/api/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// Ingest Router, mounted beside the v1 router on the same engine.
+// Device send readings every minute, so the route authenticate by device api key
+// and is not recorded by RecordApi middleware
+func Setup(app *gin.Engine) {
+	measurementHandler := handler.GetMeasurementHandler()
+	app.POST("/ingest", middleware.AuthDevice(), measurementHandler.Ingest)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/adiatma85/golang-rest-template-api/internal/api/handler"
 	"github.com/adiatma85/golang-rest-template-api/internal/api/middleware"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
 	"github.com/gin-gonic/gin"
 )
 
@@ -27,44 +28,186 @@ func Setup() *gin.Engine {
 			param.ErrorMessage,
 		)
 	}))
+	// Metrics is before recovery, so the panic is counted as internal server error
+	app.Use(middleware.Metrics())
 	app.Use(gin.Recovery())
 	app.Use(middleware.CORS())
-	app.Use(middleware.RecordApi())
-	app.NoMethod(middleware.NoMethodHandler())
-	app.NoRoute(middleware.NoRouteHandler())
+	// Api record is attached to v1 routes only, so other router on this engine (e.g. ingest) is not recorded
+	app.NoMethod(middleware.RecordApi(), middleware.NoMethodHandler())
+	app.NoRoute(middleware.RecordApi(), middleware.NoRouteHandler())
+
+	// Metrics for Prometheus and probes for orchestrator, outside of v1 routes so they are not recorded as api traffic
+	app.GET("/metrics", middleware.AuthMetrics(config.GetConfig().Metrics.Token), handler.GetMetricsHandler().GetMetrics)
+	healthHandler := handler.GetHealthHandler()
+	{
+		app.GET("/healthz", healthHandler.Liveness)
+		app.GET("/readyz", healthHandler.Readiness)
+	}
 
 	// Routes for v1
-	v1Route := app.Group("/api/v1")
+	v1Route := app.Group("/api/v1", middleware.RecordApi())
 	recordApiHandler := handler.GetRecordApiHandler()
 	{
 		v1Route.GET("", func(ctx *gin.Context) {
 			ctx.JSON(http.StatusOK, "Welcome")
 		})
-		v1Route.GET("records", recordApiHandler.GetAllRecord)
+		// Traffic of every user and route, so it is only for admin
+		v1Route.GET("records", middleware.AuthJWT(), middleware.Authorize(middleware.PermissionTrafficRead), recordApiHandler.GetAllRecord)
+	}
+
+	// AuthGroup
+	authGroup := v1Route.Group("auth")
+	authHandler := handler.GetAuthHandler()
+	{
+		authGroup.POST("register", authHandler.Register)
+		authGroup.POST("login", authHandler.Login)
+		authGroup.POST("refresh", authHandler.Refresh)
+		authGroup.POST("logout", middleware.AuthJWT(), authHandler.Logout)
+	}
+
+	// UserGroup
+	userGroup := v1Route.Group("users", middleware.AuthJWT())
+	userHandler := handler.GetUserHandler()
+	{
+		userGroup.PUT(":userId/role", middleware.Authorize(middleware.PermissionUserManage), userHandler.UpdateRole)
+	}
+
+	// OrganizationGroup
+	organizationGroup := v1Route.Group("organizations", middleware.AuthJWT())
+	organizationHandler := handler.GetOrganizationHandler()
+	{
+		organizationGroup.GET("", organizationHandler.GetAllOrganization)
+		organizationGroup.POST("", middleware.Authorize(middleware.PermissionOrganizationManage), organizationHandler.CreateOrganization)
+		organizationGroup.POST(":organizationId/members", middleware.Authorize(middleware.PermissionOrganizationManage), organizationHandler.AddMember)
 	}
 
 	// FarmGroup
-	farmGroup := v1Route.Group("farm")
+	farmGroup := v1Route.Group("farm", middleware.AuthJWT())
 	farmHandler := handler.GetFarmHandler()
 	{
-		farmGroup.GET("", farmHandler.GetAllFarm)
-		farmGroup.GET(":farmId", farmHandler.GetById)
-		farmGroup.POST("", farmHandler.CreateFarm)
+		farmGroup.GET("", middleware.Authorize(middleware.PermissionFarmRead), farmHandler.GetAllFarm)
+		farmGroup.GET(":farmId", middleware.Authorize(middleware.PermissionFarmRead), farmHandler.GetById)
+		farmGroup.GET(":farmId/geojson", middleware.Authorize(middleware.PermissionFarmRead), farmHandler.GetGeoJson)
+		farmGroup.GET(":farmId/yield", middleware.Authorize(middleware.PermissionFarmRead), handler.GetHarvestHandler().GetFarmYield)
+		farmGroup.POST("", middleware.Authorize(middleware.PermissionFarmWrite), farmHandler.CreateFarm)
 		// Non-standar PUT route according to requirement
-		farmGroup.PUT("", farmHandler.Update)
-		farmGroup.DELETE(":farmId", farmHandler.Delete)
+		farmGroup.PUT("", middleware.Authorize(middleware.PermissionFarmWrite), farmHandler.Update)
+		farmGroup.DELETE(":farmId", middleware.Authorize(middleware.PermissionFarmDelete), farmHandler.Delete)
+	}
+
+	// Inventory of Farm
+	inventoryHandler := handler.GetInventoryHandler()
+	{
+		farmGroup.GET(":farmId/inventory", middleware.Authorize(middleware.PermissionFarmRead), inventoryHandler.GetAllItem)
+		farmGroup.GET(":farmId/inventory/:itemId", middleware.Authorize(middleware.PermissionFarmRead), inventoryHandler.GetItemById)
+		farmGroup.POST(":farmId/inventory", middleware.Authorize(middleware.PermissionFarmWrite), inventoryHandler.CreateItem)
+		farmGroup.PUT(":farmId/inventory/:itemId", middleware.Authorize(middleware.PermissionFarmWrite), inventoryHandler.UpdateItem)
+		farmGroup.DELETE(":farmId/inventory/:itemId", middleware.Authorize(middleware.PermissionFarmDelete), inventoryHandler.DeleteItem)
+		farmGroup.GET(":farmId/inventory/:itemId/movements", middleware.Authorize(middleware.PermissionFarmRead), inventoryHandler.GetAllMovement)
+		farmGroup.POST(":farmId/inventory/:itemId/movements", middleware.Authorize(middleware.PermissionInventoryWrite), inventoryHandler.CreateMovement)
 	}
 
 	// PondGroup
-	pondGroup := v1Route.Group("pond")
+	pondGroup := v1Route.Group("pond", middleware.AuthJWT())
 	pondHandler := handler.GetPondHandler()
 	{
-		pondGroup.GET("", pondHandler.GetAllPond)
-		pondGroup.GET(":pondId", pondHandler.GetById)
-		pondGroup.POST("", pondHandler.CreatePond)
+		pondGroup.GET("", middleware.Authorize(middleware.PermissionPondRead), pondHandler.GetAllPond)
+		pondGroup.GET(":pondId", middleware.Authorize(middleware.PermissionPondRead), pondHandler.GetById)
+		pondGroup.POST("", middleware.Authorize(middleware.PermissionPondWrite), pondHandler.CreatePond)
 		// Non-standar PUT route according to requirement
-		pondGroup.PUT("", pondHandler.Update)
-		pondGroup.DELETE(":pondId", pondHandler.Delete)
+		pondGroup.PUT("", middleware.Authorize(middleware.PermissionPondWrite), pondHandler.Update)
+		pondGroup.DELETE(":pondId", middleware.Authorize(middleware.PermissionPondDelete), pondHandler.Delete)
+	}
+
+	// Water Quality Reading of Pond
+	waterQualityReadingHandler := handler.GetWaterQualityReadingHandler()
+	{
+		pondGroup.GET(":pondId/readings", middleware.Authorize(middleware.PermissionPondRead), waterQualityReadingHandler.GetAllReading)
+		pondGroup.GET(":pondId/readings/aggregate", middleware.Authorize(middleware.PermissionPondRead), handler.GetMeasurementHandler().GetAggregate)
+		pondGroup.POST(":pondId/readings", middleware.Authorize(middleware.PermissionPondWrite), waterQualityReadingHandler.CreateReading)
+		pondGroup.DELETE(":pondId/readings/:readingId", middleware.Authorize(middleware.PermissionPondWrite), waterQualityReadingHandler.DeleteReading)
+	}
+
+	// Device of Pond
+	deviceHandler := handler.GetDeviceHandler()
+	{
+		pondGroup.GET(":pondId/devices", middleware.Authorize(middleware.PermissionPondRead), deviceHandler.GetAllDevice)
+		pondGroup.POST(":pondId/devices", middleware.Authorize(middleware.PermissionDeviceManage), deviceHandler.CreateDevice)
+		pondGroup.DELETE(":pondId/devices/:deviceId", middleware.Authorize(middleware.PermissionDeviceManage), deviceHandler.DeleteDevice)
+	}
+
+	// Measurement of Pond, sent by its devices through ingest router
+	measurementHandler := handler.GetMeasurementHandler()
+	{
+		pondGroup.GET(":pondId/measurements", middleware.Authorize(middleware.PermissionPondRead), measurementHandler.GetAllMeasurement)
+	}
+
+	// Cycle of Pond
+	cycleHandler := handler.GetCycleHandler()
+	{
+		pondGroup.GET(":pondId/cycles", middleware.Authorize(middleware.PermissionPondRead), cycleHandler.GetAllCycle)
+		pondGroup.GET(":pondId/cycles/:cycleId", middleware.Authorize(middleware.PermissionPondRead), cycleHandler.GetById)
+		pondGroup.POST(":pondId/cycles", middleware.Authorize(middleware.PermissionPondWrite), cycleHandler.CreateCycle)
+		pondGroup.PUT(":pondId/cycles/:cycleId", middleware.Authorize(middleware.PermissionPondWrite), cycleHandler.Update)
+		pondGroup.PUT(":pondId/cycles/:cycleId/status", middleware.Authorize(middleware.PermissionPondWrite), cycleHandler.ChangeStatus)
+	}
+
+	// Growth Sample of Pond
+	growthSampleHandler := handler.GetGrowthSampleHandler()
+	{
+		pondGroup.GET(":pondId/samples", middleware.Authorize(middleware.PermissionPondRead), growthSampleHandler.GetAllSample)
+		pondGroup.POST(":pondId/samples", middleware.Authorize(middleware.PermissionPondWrite), growthSampleHandler.CreateSample)
+		pondGroup.DELETE(":pondId/samples/:sampleId", middleware.Authorize(middleware.PermissionPondWrite), growthSampleHandler.DeleteSample)
+	}
+
+	// Mortality Log of Pond
+	mortalityLogHandler := handler.GetMortalityLogHandler()
+	{
+		pondGroup.GET(":pondId/mortality", middleware.Authorize(middleware.PermissionPondRead), mortalityLogHandler.GetAllMortality)
+		pondGroup.GET(":pondId/mortality/survival", middleware.Authorize(middleware.PermissionPondRead), mortalityLogHandler.GetSurvival)
+		pondGroup.GET(":pondId/mortality/spikes", middleware.Authorize(middleware.PermissionPondRead), mortalityLogHandler.GetSpikes)
+		pondGroup.POST(":pondId/mortality", middleware.Authorize(middleware.PermissionPondWrite), mortalityLogHandler.CreateMortality)
+		pondGroup.DELETE(":pondId/mortality/:mortalityId", middleware.Authorize(middleware.PermissionPondWrite), mortalityLogHandler.DeleteMortality)
+	}
+
+	// Harvest of Pond
+	harvestHandler := handler.GetHarvestHandler()
+	{
+		pondGroup.GET(":pondId/harvests", middleware.Authorize(middleware.PermissionPondRead), harvestHandler.GetAllHarvest)
+		pondGroup.POST(":pondId/harvests", middleware.Authorize(middleware.PermissionPondWrite), harvestHandler.CreateHarvest)
+	}
+
+	// Treatment Log of Pond
+	treatmentLogHandler := handler.GetTreatmentLogHandler()
+	{
+		pondGroup.GET(":pondId/treatments", middleware.Authorize(middleware.PermissionPondRead), treatmentLogHandler.GetAllTreatment)
+		pondGroup.GET(":pondId/harvest-clearance", middleware.Authorize(middleware.PermissionPondRead), treatmentLogHandler.GetHarvestClearance)
+		pondGroup.POST(":pondId/treatments", middleware.Authorize(middleware.PermissionPondWrite), treatmentLogHandler.CreateTreatment)
+		pondGroup.DELETE(":pondId/treatments/:treatmentId", middleware.Authorize(middleware.PermissionPondWrite), treatmentLogHandler.DeleteTreatment)
+	}
+
+	// Feeding Log of Pond
+	feedingLogHandler := handler.GetFeedingLogHandler()
+	{
+		pondGroup.GET(":pondId/feeding", middleware.Authorize(middleware.PermissionPondRead), feedingLogHandler.GetAllFeeding)
+		pondGroup.GET(":pondId/feeding/summary", middleware.Authorize(middleware.PermissionPondRead), feedingLogHandler.GetSummary)
+		pondGroup.POST(":pondId/feeding", middleware.Authorize(middleware.PermissionPondWrite), feedingLogHandler.CreateFeeding)
+		pondGroup.DELETE(":pondId/feeding/:feedingId", middleware.Authorize(middleware.PermissionPondWrite), feedingLogHandler.DeleteFeeding)
+	}
+
+	// Threshold of Pond
+	alertHandler := handler.GetAlertHandler()
+	{
+		pondGroup.GET(":pondId/thresholds", middleware.Authorize(middleware.PermissionPondRead), alertHandler.GetAllThreshold)
+		pondGroup.PUT(":pondId/thresholds", middleware.Authorize(middleware.PermissionThresholdWrite), alertHandler.UpsertThreshold)
+	}
+
+	// AlertGroup
+	alertGroup := v1Route.Group("alerts", middleware.AuthJWT())
+	{
+		alertGroup.GET("", middleware.Authorize(middleware.PermissionPondRead), alertHandler.GetAllAlert)
+		alertGroup.POST(":alertId/acknowledge", middleware.Authorize(middleware.PermissionAlertWrite), alertHandler.Acknowledge)
+		alertGroup.POST(":alertId/resolve", middleware.Authorize(middleware.PermissionAlertWrite), alertHandler.Resolve)
 	}
 
 	return app
@@ -0,0 +1,48 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	"gorm.io/gorm"
+)
+
+// Error when the admin email is set without password
+var ErrAdminPasswordRequired = errors.New("ADMIN_PASSWORD must be set to create the admin")
+
+// Func to create the admin from configuration when no user has its email.
+// Existing user is left as it is, so the password changed later is not reset
+func SeedAdmin(configuration config.AdminConfiguration) error {
+	if configuration.Email == "" {
+		return nil
+	}
+	if configuration.Password == "" {
+		return ErrAdminPasswordRequired
+	}
+
+	userRepo := repository.GetUserRepository()
+	existedUser, err := userRepo.GetByModel(models.User{Email: configuration.Email})
+	if existedUser != nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to look up admin: %w", err)
+	}
+
+	hashedPassword, err := crypto.GetPasswordCryptoHelper().HashAndSalt([]byte(configuration.Password))
+	if err != nil {
+		return err
+	}
+
+	_, err = userRepo.Create(models.User{
+		Name:     configuration.Name,
+		Email:    configuration.Email,
+		Password: hashedPassword,
+		Role:     models.RoleAdmin,
+	})
+	return err
+}
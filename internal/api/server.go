@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+)
+
+// Error when only one of TLS cert and key file is set
+var ErrIncompleteTls = errors.New("both SERVER_TLS_CERT_FILE and SERVER_TLS_KEY_FILE must be set to serve TLS")
+
+// Http server that drain the in-flight requests before it stop
+type Server struct {
+	*http.Server
+	TlsCertFile     string
+	TlsKeyFile      string
+	ShutdownTimeout time.Duration
+}
+
+// Func to create Server of the handler with the server configuration
+func NewServer(handler http.Handler, configuration config.ServerConnection) *Server {
+	second := func(value int) time.Duration {
+		return time.Duration(value) * time.Second
+	}
+	return &Server{
+		Server: &http.Server{
+			Addr:              ":" + configuration.Port,
+			Handler:           handler,
+			ReadTimeout:       second(configuration.ReadTimeoutSecond),
+			ReadHeaderTimeout: second(configuration.ReadHeaderTimeoutSecond),
+			WriteTimeout:      second(configuration.WriteTimeoutSecond),
+			IdleTimeout:       second(configuration.IdleTimeoutSecond),
+			MaxHeaderBytes:    configuration.MaxHeaderBytes,
+		},
+		TlsCertFile:     configuration.TlsCertFile,
+		TlsKeyFile:      configuration.TlsKeyFile,
+		ShutdownTimeout: second(configuration.ShutdownTimeoutSecond),
+	}
+}
+
+// Func to listen on the address of the server and run it until the context is done
+func (server *Server) ListenAndRun(ctx context.Context) error {
+	if (server.TlsCertFile == "") != (server.TlsKeyFile == "") {
+		return ErrIncompleteTls
+	}
+
+	listener, err := net.Listen("tcp", server.Addr)
+	if err != nil {
+		return err
+	}
+	return server.Run(ctx, listener)
+}
+
+// Func to serve on the listener until the context is done, then shutdown gracefully.
+// In-flight requests are drained until the shutdown timeout (zero means no deadline),
+// then the remaining connections are closed and the deadline error is returned
+func (server *Server) Run(ctx context.Context, listener net.Listener) error {
+	if (server.TlsCertFile == "") != (server.TlsKeyFile == "") {
+		return ErrIncompleteTls
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		if server.TlsCertFile != "" {
+			served <- server.ServeTLS(listener, server.TlsCertFile, server.TlsKeyFile)
+		} else {
+			served <- server.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-served:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx := context.Background()
+	if server.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		shutdownCtx, cancel = context.WithTimeout(shutdownCtx, server.ShutdownTimeout)
+		defer cancel()
+	}
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		server.Close()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Error when the aggregation query is not valid
+var ErrInvalidQuery = errors.New("invalid aggregation query")
+
+// Limit of interval and number of buckets in single query
+const (
+	MinInterval = time.Minute
+	MaxBuckets  = 1000
+)
+
+// Available aggregate functions
+const (
+	FunctionAvg = "avg"
+	FunctionMin = "min"
+	FunctionMax = "max"
+)
+
+// Metric name that can be aggregated and its parameter (column of measurement)
+var Metrics = map[string]string{
+	"do":                            models.ParameterDissolvedOxygen,
+	models.ParameterDissolvedOxygen: models.ParameterDissolvedOxygen,
+	models.ParameterPh:              models.ParameterPh,
+	models.ParameterTemperature:     models.ParameterTemperature,
+	models.ParameterSalinity:        models.ParameterSalinity,
+	models.ParameterAmmonia:         models.ParameterAmmonia,
+	models.ParameterNitrite:         models.ParameterNitrite,
+}
+
+// Struct of single time bucket, Value is nil when there is no measurement in the bucket
+type Bucket struct {
+	Start time.Time `json:"start"`
+	Value *float64  `json:"value"`
+	Count int64     `json:"count"`
+}
+
+// Struct of aggregation query, buckets are aligned to the unix epoch (UTC)
+// and cover From (inclusive) until To (exclusive)
+type Query struct {
+	Parameter string
+	Function  string
+	Interval  time.Duration
+	From      time.Time
+	To        time.Time
+}
+
+// Func to build aggregation query from the request,
+// interval is Go duration (e.g. 15m, 1h) or number of days (e.g. 1d)
+func NewQuery(metric, function, interval string, from, to time.Time) (Query, error) {
+	parameter, ok := Metrics[metric]
+	if !ok {
+		return Query{}, fmt.Errorf("%w: unknown metric %q", ErrInvalidQuery, metric)
+	}
+
+	if function == "" {
+		function = FunctionAvg
+	}
+	if function != FunctionAvg && function != FunctionMin && function != FunctionMax {
+		return Query{}, fmt.Errorf("%w: fn must be one of avg, min or max", ErrInvalidQuery)
+	}
+
+	duration, err := parseInterval(interval)
+	if err != nil {
+		return Query{}, err
+	}
+
+	if !from.Before(to) {
+		return Query{}, fmt.Errorf("%w: from must be before to", ErrInvalidQuery)
+	}
+
+	query := Query{Parameter: parameter, Function: function, Interval: duration, From: BucketStart(from, duration), To: to}
+	if count := len(query.Buckets()); count > MaxBuckets {
+		return Query{}, fmt.Errorf("%w: %d buckets requested, at most %d buckets are allowed", ErrInvalidQuery, count, MaxBuckets)
+	}
+	return query, nil
+}
+
+// Func to get the start of every bucket in the query
+func (query Query) Buckets() []time.Time {
+	var starts []time.Time
+	for start := query.From; start.Before(query.To); start = start.Add(query.Interval) {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// Func to get the start of the bucket that contains the time
+func BucketStart(at time.Time, interval time.Duration) time.Time {
+	seconds := int64(interval / time.Second)
+	epoch := at.Unix()
+	start := epoch - epoch%seconds
+	if epoch < 0 && epoch%seconds != 0 {
+		start -= seconds
+	}
+	return time.Unix(start, 0).UTC()
+}
+
+// Helper to parse the interval, it must be whole seconds and at least one minute
+func parseInterval(interval string) (time.Duration, error) {
+	var duration time.Duration
+	var err error
+	if days := strings.TrimSuffix(interval, "d"); days != interval {
+		var count int
+		count, err = strconv.Atoi(days)
+		duration = time.Duration(count) * 24 * time.Hour
+	} else {
+		duration, err = time.ParseDuration(interval)
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("%w: interval %q is not a duration", ErrInvalidQuery, interval)
+	}
+	if duration < MinInterval || duration%time.Second != 0 {
+		return 0, fmt.Errorf("%w: interval must be whole seconds and at least %s", ErrInvalidQuery, MinInterval)
+	}
+	return duration, nil
+}
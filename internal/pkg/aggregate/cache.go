@@ -0,0 +1,138 @@
+package aggregate
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Key of cached bucket
+type cacheKey struct {
+	pondId    uint
+	parameter string
+	function  string
+	interval  time.Duration
+	start     int64
+}
+
+// Entry of the least recently used list
+type cacheEntry struct {
+	key       cacheKey
+	bucket    Bucket
+	expiresAt time.Time
+}
+
+// Cache of completed buckets per pond, the least recently used bucket is evicted when it is full.
+// Bucket expires after the ttl, so measurement stored through another instance is seen after at most the ttl.
+// It is safe for concurrent use
+type Cache struct {
+	mutex    sync.Mutex
+	capacity int
+	ttl      time.Duration
+	order    *list.List
+	entries  map[cacheKey]*list.Element
+	ponds    map[uint]map[cacheKey]struct{}
+}
+
+// Func to create new Cache that hold at most capacity buckets, each for the ttl (zero means it does not expire)
+func NewCache(capacity int, ttl time.Duration) *Cache {
+	return &Cache{
+		capacity: capacity,
+		ttl:      ttl,
+		order:    list.New(),
+		entries:  map[cacheKey]*list.Element{},
+		ponds:    map[uint]map[cacheKey]struct{}{},
+	}
+}
+
+// Func to get cached bucket of the pond
+func (cache *Cache) Get(pondId uint, query Query, start time.Time) (Bucket, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	element, ok := cache.entries[newCacheKey(pondId, query, start)]
+	if !ok {
+		return Bucket{}, false
+	}
+	entry := element.Value.(*cacheEntry)
+	if !entry.expiresAt.IsZero() && !time.Now().Before(entry.expiresAt) {
+		cache.remove(element)
+		return Bucket{}, false
+	}
+	cache.order.MoveToFront(element)
+	return entry.bucket, true
+}
+
+// Func to cache bucket of the pond
+func (cache *Cache) Set(pondId uint, query Query, bucket Bucket) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	var expiresAt time.Time
+	if cache.ttl > 0 {
+		expiresAt = time.Now().Add(cache.ttl)
+	}
+
+	key := newCacheKey(pondId, query, bucket.Start)
+	if element, ok := cache.entries[key]; ok {
+		entry := element.Value.(*cacheEntry)
+		entry.bucket = bucket
+		entry.expiresAt = expiresAt
+		cache.order.MoveToFront(element)
+		return
+	}
+
+	cache.entries[key] = cache.order.PushFront(&cacheEntry{key: key, bucket: bucket, expiresAt: expiresAt})
+	if cache.ponds[pondId] == nil {
+		cache.ponds[pondId] = map[cacheKey]struct{}{}
+	}
+	cache.ponds[pondId][key] = struct{}{}
+
+	for cache.capacity > 0 && cache.order.Len() > cache.capacity {
+		cache.remove(cache.order.Back())
+	}
+}
+
+// Func to remove cached buckets of the pond that overlap the time range,
+// called when new measurements are stored in the range
+func (cache *Cache) Invalidate(pondId uint, from, to time.Time) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for key := range cache.ponds[pondId] {
+		start := time.Unix(key.start, 0)
+		end := start.Add(key.interval)
+		if end.After(from) && !start.After(to) {
+			cache.remove(cache.entries[key])
+		}
+	}
+}
+
+// Func to get the number of cached buckets
+func (cache *Cache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// Helper to remove element from every index, the mutex must be held
+func (cache *Cache) remove(element *list.Element) {
+	key := element.Value.(*cacheEntry).key
+	cache.order.Remove(element)
+	delete(cache.entries, key)
+	delete(cache.ponds[key.pondId], key)
+	if len(cache.ponds[key.pondId]) == 0 {
+		delete(cache.ponds, key.pondId)
+	}
+}
+
+// Helper to build the key of bucket
+func newCacheKey(pondId uint, query Query, start time.Time) cacheKey {
+	return cacheKey{
+		pondId:    pondId,
+		parameter: query.Parameter,
+		function:  query.Function,
+		interval:  query.Interval,
+		start:     start.Unix(),
+	}
+}
@@ -0,0 +1,207 @@
+package alert
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"gorm.io/gorm"
+)
+
+var alertEngine *Engine
+
+// Contract for Alert Engine
+type EngineInterface interface {
+	Evaluate(reading models.WaterQualityReading) ([]models.Alert, error)
+	EvaluateMeasurements(measurements []models.Measurement) ([]models.Alert, error)
+	EffectiveThresholds(pondId uint) ([]models.PondThreshold, error)
+}
+
+// Engine evaluate water quality reading and device measurement against the pond thresholds
+// and persist alert when the value is out of range
+type Engine struct {
+	PondThresholdRepository repository.PondThresholdRepositoryInterface
+	AlertRepository         repository.AlertRepositoryInterface
+}
+
+// Func to get Alert Engine instance
+func GetAlertEngine() EngineInterface {
+	if alertEngine == nil {
+		alertEngine = &Engine{
+			PondThresholdRepository: repository.GetPondThresholdRepository(),
+			AlertRepository:         repository.GetAlertRepository(),
+		}
+	}
+	return alertEngine
+}
+
+// Helper to get pointer of float
+func bound(value float64) *float64 {
+	return &value
+}
+
+// Default safe range that is used when the pond does not configure the parameter
+var DefaultThresholds = map[string]models.PondThreshold{
+	models.ParameterDissolvedOxygen: {Min: bound(4), CriticalMin: bound(3)},
+	models.ParameterPh:              {Min: bound(6.5), Max: bound(9), CriticalMin: bound(6), CriticalMax: bound(9.5)},
+	models.ParameterTemperature:     {Min: bound(25), Max: bound(32), CriticalMin: bound(20), CriticalMax: bound(35)},
+	models.ParameterSalinity:        {Min: bound(5), Max: bound(35), CriticalMin: bound(2), CriticalMax: bound(45)},
+	models.ParameterAmmonia:         {Max: bound(0.5), CriticalMax: bound(1)},
+	models.ParameterNitrite:         {Max: bound(0.5), CriticalMax: bound(1)},
+}
+
+// Func to get thresholds of pond, the configured one replace the default
+func (engine *Engine) EffectiveThresholds(pondId uint) ([]models.PondThreshold, error) {
+	configuredThresholds, err := engine.PondThresholdRepository.GetAllByPond(pondId)
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds := map[string]models.PondThreshold{}
+	for parameter, threshold := range DefaultThresholds {
+		threshold.PondId = pondId
+		threshold.Parameter = parameter
+		thresholds[parameter] = threshold
+	}
+	for _, threshold := range *configuredThresholds {
+		thresholds[threshold.Parameter] = threshold
+	}
+
+	effectiveThresholds := make([]models.PondThreshold, 0, len(thresholds))
+	for _, threshold := range thresholds {
+		effectiveThresholds = append(effectiveThresholds, threshold)
+	}
+	sort.Slice(effectiveThresholds, func(i, j int) bool {
+		return effectiveThresholds[i].Parameter < effectiveThresholds[j].Parameter
+	})
+	return effectiveThresholds, nil
+}
+
+// Func to get severity of the value, empty string means it is in safe range
+func Severity(threshold models.PondThreshold, value float64) string {
+	switch {
+	case threshold.CriticalMin != nil && value < *threshold.CriticalMin,
+		threshold.CriticalMax != nil && value > *threshold.CriticalMax:
+		return models.AlertSeverityCritical
+	case threshold.Min != nil && value < *threshold.Min,
+		threshold.Max != nil && value > *threshold.Max:
+		return models.AlertSeverityWarning
+	}
+	return ""
+}
+
+// Func to evaluate every measured parameter of reading.
+// Unresolved alert of the same parameter is updated (and escalated) instead of creating new one
+func (engine *Engine) Evaluate(reading models.WaterQualityReading) ([]models.Alert, error) {
+	return engine.evaluate(reading.PondId, reading.Measurements(), models.Alert{ReadingId: reading.ID})
+}
+
+// Func to evaluate batch of measurements sent by device.
+// Only the latest measurement of every pond is evaluated, the older ones are already superseded by it
+func (engine *Engine) EvaluateMeasurements(measurements []models.Measurement) ([]models.Alert, error) {
+	alerts := []models.Alert{}
+	for _, measurement := range LatestMeasurements(measurements) {
+		source := models.Alert{}
+		// Measurement that is not stored (e.g. already sent) does not have id
+		if measurement.ID != 0 {
+			measurementId := measurement.ID
+			source.MeasurementId = &measurementId
+		}
+
+		measurementAlerts, err := engine.evaluate(measurement.PondId, measurement.Measurements(), source)
+		alerts = append(alerts, measurementAlerts...)
+		if err != nil {
+			return alerts, err
+		}
+	}
+	return alerts, nil
+}
+
+// Func to get the latest measurement of every pond, in the order of the pond id
+func LatestMeasurements(measurements []models.Measurement) []models.Measurement {
+	latestByPond := map[uint]models.Measurement{}
+	for _, measurement := range measurements {
+		latest, ok := latestByPond[measurement.PondId]
+		if !ok || measurement.MeasuredAt.After(latest.MeasuredAt) {
+			latestByPond[measurement.PondId] = measurement
+		}
+	}
+
+	latestMeasurements := make([]models.Measurement, 0, len(latestByPond))
+	for _, measurement := range latestByPond {
+		latestMeasurements = append(latestMeasurements, measurement)
+	}
+	sort.Slice(latestMeasurements, func(i, j int) bool {
+		return latestMeasurements[i].PondId < latestMeasurements[j].PondId
+	})
+	return latestMeasurements
+}
+
+// Func to merge the out of range value into the unresolved alert. The source and the bound are replaced
+// by the latest ones, and the severity is only raised
+func Merge(existedAlert models.Alert, source models.Alert, threshold models.PondThreshold, value float64, severity string) models.Alert {
+	existedAlert.ReadingId = source.ReadingId
+	existedAlert.MeasurementId = source.MeasurementId
+	existedAlert.Value = value
+	existedAlert.Min = threshold.Min
+	existedAlert.Max = threshold.Max
+	existedAlert.Occurrences++
+	if severity == models.AlertSeverityCritical {
+		existedAlert.Severity = severity
+	}
+	return existedAlert
+}
+
+// Helper to evaluate the measured parameters of pond, the source is the reading or measurement that raise the alert
+func (engine *Engine) evaluate(pondId uint, measurements map[string]float64, source models.Alert) ([]models.Alert, error) {
+	thresholds, err := engine.EffectiveThresholds(pondId)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []models.Alert{}
+	for _, threshold := range thresholds {
+		value, measured := measurements[threshold.Parameter]
+		if !measured {
+			continue
+		}
+
+		severity := Severity(threshold, value)
+		if severity == "" {
+			continue
+		}
+
+		existedAlert, err := engine.AlertRepository.GetUnresolved(pondId, threshold.Parameter)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return alerts, err
+		}
+
+		if existedAlert != nil {
+			mergedAlert := Merge(*existedAlert, source, threshold, value, severity)
+			if err := engine.AlertRepository.Merge(&mergedAlert); err != nil {
+				return alerts, err
+			}
+			alerts = append(alerts, mergedAlert)
+			continue
+		}
+
+		newAlert, err := engine.AlertRepository.Create(models.Alert{
+			PondId:        pondId,
+			ReadingId:     source.ReadingId,
+			MeasurementId: source.MeasurementId,
+			Parameter:     threshold.Parameter,
+			Value:         value,
+			Min:           threshold.Min,
+			Max:           threshold.Max,
+			Severity:      severity,
+			Status:        models.AlertStatusOpen,
+			Occurrences:   1,
+		})
+		if err != nil {
+			return alerts, err
+		}
+		alerts = append(alerts, newAlert)
+	}
+	return alerts, nil
+}
@@ -15,6 +15,12 @@ type Configuration struct {
 	Database_Test DatabaseTestConfiguration
 	Cloudinary    StorageCloudinary
 	Server        ServerConnection
+	Stocking      StockingConfiguration
+	Ingest        IngestConfiguration
+	Mqtt          MqttConfiguration
+	RecordApi     RecordApiConfiguration
+	Admin         AdminConfiguration
+	Metrics       MetricsConfiguration
 }
 
 // Struct of Database Configuration instance.
@@ -52,12 +58,72 @@ type StorageCloudinary struct {
 }
 
 // Struct of Server Configuration instance.
+// Timeouts are in seconds, zero means no timeout. TLS is served when both cert and key file are set
 type ServerConnection struct {
-	Port        string `mapstructure:"SERVER_PORT"`
-	Secret      string `mapstructure:"SERVER_SECRET"`
-	Mode        string `mapstructure:"SERVER_MODE"`
-	Name        string `mapstructure:"SERVER_NAME"`
-	ExpiresHour int64  `mapstructure:"SERVER_EXPIRES_HOUR"`
+	Port                    string `mapstructure:"SERVER_PORT"`
+	Secret                  string `mapstructure:"SERVER_SECRET"`
+	Mode                    string `mapstructure:"SERVER_MODE"`
+	Name                    string `mapstructure:"SERVER_NAME"`
+	AccessExpiresMinute     int64  `mapstructure:"SERVER_ACCESS_EXPIRES_MINUTE"`
+	RefreshExpiresHour      int64  `mapstructure:"SERVER_REFRESH_EXPIRES_HOUR"`
+	ReadTimeoutSecond       int    `mapstructure:"SERVER_READ_TIMEOUT_SECOND"`
+	ReadHeaderTimeoutSecond int    `mapstructure:"SERVER_READ_HEADER_TIMEOUT_SECOND"`
+	WriteTimeoutSecond      int    `mapstructure:"SERVER_WRITE_TIMEOUT_SECOND"`
+	IdleTimeoutSecond       int    `mapstructure:"SERVER_IDLE_TIMEOUT_SECOND"`
+	ShutdownTimeoutSecond   int    `mapstructure:"SERVER_SHUTDOWN_TIMEOUT_SECOND"`
+	MaxHeaderBytes          int    `mapstructure:"SERVER_MAX_HEADER_BYTES"`
+	TlsCertFile             string `mapstructure:"SERVER_TLS_CERT_FILE"`
+	TlsKeyFile              string `mapstructure:"SERVER_TLS_KEY_FILE"`
+}
+
+// Struct of Stocking Configuration instance.
+// Maximum stocking density of pond type, per m² for earthen and lined, per m³ for tank and RAS
+type StockingConfiguration struct {
+	MaxDensityEarthen float64 `mapstructure:"STOCKING_MAX_DENSITY_EARTHEN"`
+	MaxDensityLined   float64 `mapstructure:"STOCKING_MAX_DENSITY_LINED"`
+	MaxDensityTank    float64 `mapstructure:"STOCKING_MAX_DENSITY_TANK"`
+	MaxDensityRas     float64 `mapstructure:"STOCKING_MAX_DENSITY_RAS"`
+}
+
+// Struct of Ingest Configuration instance.
+// Limit of readings and body size that device can send in single request
+type IngestConfiguration struct {
+	MaxBatchSize int   `mapstructure:"INGEST_MAX_BATCH_SIZE"`
+	MaxBodyBytes int64 `mapstructure:"INGEST_MAX_BODY_BYTES"`
+}
+
+// Struct of MQTT Configuration instance.
+// Topic must have two single level wildcards, the first is the farm id and the second is the pond id
+type MqttConfiguration struct {
+	Enabled   bool   `mapstructure:"MQTT_ENABLED"`
+	BrokerUrl string `mapstructure:"MQTT_BROKER_URL"`
+	ClientId  string `mapstructure:"MQTT_CLIENT_ID"`
+	Username  string `mapstructure:"MQTT_USERNAME"`
+	Password  string `mapstructure:"MQTT_PASSWORD"`
+	Topic     string `mapstructure:"MQTT_TOPIC"`
+	Qos       byte   `mapstructure:"MQTT_QOS"`
+}
+
+// Struct of Record Api Configuration instance.
+// Traffic is counted in memory and flushed every interval, or earlier when the pending keys reach the maximum.
+// While flush keeps failing, at most ten times the maximum is kept and new keys are dropped
+type RecordApiConfiguration struct {
+	FlushIntervalSecond int `mapstructure:"RECORD_API_FLUSH_INTERVAL_SECOND"`
+	MaxPending          int `mapstructure:"RECORD_API_MAX_PENDING"`
+}
+
+// Struct of Admin Configuration instance.
+// The admin is created on start when the email is set and no user has it, registered users are viewer
+type AdminConfiguration struct {
+	Name     string `mapstructure:"ADMIN_NAME"`
+	Email    string `mapstructure:"ADMIN_EMAIL"`
+	Password string `mapstructure:"ADMIN_PASSWORD"`
+}
+
+// Struct of Metrics Configuration instance.
+// The metrics endpoint need the token as bearer token, it is disabled when the token is empty
+type MetricsConfiguration struct {
+	Token string `mapstructure:"METRICS_TOKEN"`
 }
 
 // Setup the configuration
@@ -67,11 +133,41 @@ func Setup(configPath string) {
 		databaseTestConfiguration DatabaseTestConfiguration
 		cloudinaryConfiguration   StorageCloudinary
 		serverConfiguration       ServerConnection
+		stockingConfiguration     StockingConfiguration
+		ingestConfiguration       IngestConfiguration
+		mqttConfiguration         MqttConfiguration
+		recordApiConfiguration    RecordApiConfiguration
+		adminConfiguration        AdminConfiguration
+		metricsConfiguration      MetricsConfiguration
 	)
 
 	viper.SetConfigFile(configPath)
 	viper.SetConfigType("env")
 
+	viper.SetDefault("SERVER_ACCESS_EXPIRES_MINUTE", 15)
+	viper.SetDefault("SERVER_REFRESH_EXPIRES_HOUR", 168)
+	viper.SetDefault("SERVER_READ_TIMEOUT_SECOND", 15)
+	viper.SetDefault("SERVER_READ_HEADER_TIMEOUT_SECOND", 5)
+	viper.SetDefault("SERVER_WRITE_TIMEOUT_SECOND", 30)
+	viper.SetDefault("SERVER_IDLE_TIMEOUT_SECOND", 60)
+	viper.SetDefault("SERVER_SHUTDOWN_TIMEOUT_SECOND", 30)
+	viper.SetDefault("SERVER_MAX_HEADER_BYTES", 1<<20)
+
+	// Common stocking density of shrimp culture, used when it is not configured
+	viper.SetDefault("STOCKING_MAX_DENSITY_EARTHEN", 60)
+	viper.SetDefault("STOCKING_MAX_DENSITY_LINED", 150)
+	viper.SetDefault("STOCKING_MAX_DENSITY_TANK", 300)
+	viper.SetDefault("STOCKING_MAX_DENSITY_RAS", 500)
+	viper.SetDefault("INGEST_MAX_BATCH_SIZE", 1000)
+	viper.SetDefault("INGEST_MAX_BODY_BYTES", 1<<20)
+	viper.SetDefault("MQTT_ENABLED", false)
+	viper.SetDefault("MQTT_CLIENT_ID", "golang-rest-template-api")
+	viper.SetDefault("MQTT_TOPIC", "farms/+/ponds/+/readings")
+	viper.SetDefault("MQTT_QOS", 1)
+	viper.SetDefault("RECORD_API_FLUSH_INTERVAL_SECOND", 5)
+	viper.SetDefault("RECORD_API_MAX_PENDING", 1000)
+	viper.SetDefault("ADMIN_NAME", "Administrator")
+
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalf("Error reading config file, %s", err)
 	}
@@ -80,12 +176,34 @@ func Setup(configPath string) {
 	unmarshalConfiguration(&databaseTestConfiguration)
 	unmarshalConfiguration(&cloudinaryConfiguration)
 	unmarshalConfiguration(&serverConfiguration)
+	unmarshalConfiguration(&stockingConfiguration)
+	unmarshalConfiguration(&ingestConfiguration)
+	unmarshalConfiguration(&mqttConfiguration)
+	unmarshalConfiguration(&recordApiConfiguration)
+	unmarshalConfiguration(&adminConfiguration)
+	unmarshalConfiguration(&metricsConfiguration)
+
+	// Token that expires immediately (or is already expired) makes every login useless
+	if serverConfiguration.AccessExpiresMinute <= 0 || serverConfiguration.RefreshExpiresHour <= 0 {
+		log.Fatalf("SERVER_ACCESS_EXPIRES_MINUTE and SERVER_REFRESH_EXPIRES_HOUR must be greater than 0")
+	}
+
+	// Api record is flushed by ticker of the interval and earlier when too many are pending
+	if recordApiConfiguration.FlushIntervalSecond <= 0 || recordApiConfiguration.MaxPending <= 0 {
+		log.Fatalf("RECORD_API_FLUSH_INTERVAL_SECOND and RECORD_API_MAX_PENDING must be greater than 0")
+	}
 
 	configuration := Configuration{
 		Database:      databaseConfiguration,
 		Database_Test: databaseTestConfiguration,
 		Cloudinary:    cloudinaryConfiguration,
 		Server:        serverConfiguration,
+		Stocking:      stockingConfiguration,
+		Ingest:        ingestConfiguration,
+		Mqtt:          mqttConfiguration,
+		RecordApi:     recordApiConfiguration,
+		Admin:         adminConfiguration,
+		Metrics:       metricsConfiguration,
 	}
 
 	Config = &configuration
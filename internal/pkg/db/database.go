@@ -1,7 +1,10 @@
 package db
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log"
 	"time"
 
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
@@ -15,15 +18,22 @@ import (
 var (
 	DB  *gorm.DB
 	err error
+
+	// Error of the last migration, nil when every model is migrated
+	migrationErr error
 )
 
+// Error when the database is not connected yet
+var ErrNotConnected = errors.New("database is not connected")
+
 // Database instance
 type Database struct {
 	*gorm.DB
 }
 
-// SetupDB is a function to open connection to database
-func SetupDB() {
+// SetupDB is a function to open connection to database.
+// Failed migration is logged and reported by MigrationError, so the api can still be inspected while it is not ready
+func SetupDB() error {
 	var db = DB
 
 	configuration := config.GetConfig()
@@ -42,27 +52,35 @@ func SetupDB() {
 	}
 
 	switch driver {
+	// Datetime is stored and read in UTC, and the session time zone is UTC too,
+	// so time computed by the database (e.g. UNIX_TIMESTAMP) does not depend on the time zone of the server
 	case "mysql":
-		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=Local", username, password, host, port, database)
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27", username, password, host, port, database)
 		db, err = gorm.Open(mysql.Open(dsn), gormConfig)
-		if err != nil {
-			fmt.Println("db err:", err)
-		}
 	case "postgres":
 		dsn := fmt.Sprintf("host=%s port=%s user=%s dbname=%s sslmode=disable password=%s", host, port, username, database, password)
 		db, err = gorm.Open(postgres.Open(dsn), gormConfig)
-		if err != nil {
-			fmt.Println("db err:", err)
-		}
+	default:
+		err = fmt.Errorf("unsupported database driver %q", driver)
+	}
+	if err != nil {
+		return err
 	}
+
 	// Set up the connection pools
-	sqlDb, _ := db.DB()
+	sqlDb, err := db.DB()
+	if err != nil {
+		return err
+	}
 	sqlDb.SetMaxIdleConns(configuration.Database.MaxIdleConns)
 	sqlDb.SetMaxOpenConns(configuration.Database.MaxOpenConns)
 	sqlDb.SetConnMaxLifetime(time.Duration(configuration.Database.MaxLifetime))
 
 	DB = db
-	migration()
+	if err := migration(); err != nil {
+		log.Println("db migration err:", err)
+	}
+	return nil
 }
 
 // Setup for testing database
@@ -78,11 +96,65 @@ func SetupTestingDb(host, username, password, port, database string) {
 	migration()
 }
 
-// AutoMigrate project models
-func migration() {
-	DB.AutoMigrate(&models.Farm{}, &models.Pond{}, &models.RecordApi{})
+// AutoMigrate project models, the error is kept for the readiness check
+func migration() error {
+	migrationErr = DB.AutoMigrate(
+		&models.Organization{},
+		&models.Farm{},
+		&models.Pond{},
+		&models.RecordApi{},
+		&models.User{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.WaterQualityReading{},
+		&models.PondThreshold{},
+		&models.Alert{},
+		&models.Cycle{},
+		&models.FeedingLog{},
+		&models.GrowthSample{},
+		&models.MortalityLog{},
+		&models.Harvest{},
+		&models.Device{},
+		&models.Measurement{},
+		&models.InventoryItem{},
+		&models.StockMovement{},
+		&models.TreatmentLog{},
+	)
+	return migrationErr
 }
 
 func GetDB() *gorm.DB {
 	return DB
 }
+
+// Func to check the connection to database
+func Ping(ctx context.Context) error {
+	if DB == nil {
+		return ErrNotConnected
+	}
+	sqlDb, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDb.PingContext(ctx)
+}
+
+// Func to get the error of the last migration, nil when every model is migrated
+func MigrationError() error {
+	if DB == nil {
+		return ErrNotConnected
+	}
+	return migrationErr
+}
+
+// Func to close the connection pool of database
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	sqlDb, err := DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDb.Close()
+}
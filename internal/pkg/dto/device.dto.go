@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/aggregate"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Api key is only returned once, when the device is registered
+type DeviceResponseDto struct {
+	models.Device
+	ApiKey string `json:"api_key,omitempty"`
+}
+
+// Accepted is the number of stored readings, Duplicated is the number of readings
+// skipped because the device already sent reading with the same timestamp
+type IngestResponseDto struct {
+	Accepted   int64 `json:"accepted"`
+	Duplicated int64 `json:"duplicated"`
+}
+
+// Buckets cover From until To, value of bucket without measurement is null
+type AggregateResponseDto struct {
+	Metric   string             `json:"metric"`
+	Function string             `json:"fn"`
+	Interval string             `json:"interval"`
+	From     time.Time          `json:"from"`
+	To       time.Time          `json:"to"`
+	Buckets  []aggregate.Bucket `json:"buckets"`
+}
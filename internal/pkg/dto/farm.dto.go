@@ -1,16 +1,23 @@
 package dto
 
 import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"gorm.io/gorm"
 )
 
 type subPond struct {
 	gorm.Model
-	Name string `json:"name"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Occupancy string `json:"occupancy"`
 }
 
 type FarmResponseDto struct {
 	gorm.Model
-	Name  string    `json:"name"`
-	Ponds []subPond `json:"ponds"`
+	Name           string         `json:"name"`
+	Latitude       *float64       `json:"latitude"`
+	Longitude      *float64       `json:"longitude"`
+	Boundary       models.Polygon `json:"boundary"`
+	OrganizationId uint           `json:"organization_id"`
+	Ponds          []subPond      `json:"ponds"`
 }
@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Fcr is nil when there is no biomass gain
+type FeedingSummaryResponseDto struct {
+	PondId          uint                  `json:"pond_id"`
+	From            *time.Time            `json:"from"`
+	To              *time.Time            `json:"to"`
+	TotalFeed       float64               `json:"total_feed"`
+	Feedings        int64                 `json:"feedings"`
+	ByFeedType      []models.FeedingTotal `json:"by_feed_type"`
+	StartingBiomass float64               `json:"starting_biomass"`
+	CurrentBiomass  float64               `json:"current_biomass"`
+	BiomassGain     float64               `json:"biomass_gain"`
+	Fcr             *float64              `json:"fcr"`
+}
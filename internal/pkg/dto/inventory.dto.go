@@ -0,0 +1,11 @@
+package dto
+
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+// OnHand is the sum of the stock movements of the item,
+// LowStock is true when it is at or below the low stock threshold
+type InventoryItemResponseDto struct {
+	models.InventoryItem
+	OnHand   float64 `json:"on_hand"`
+	LowStock bool    `json:"low_stock"`
+}
@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/mortality"
+)
+
+// InitialCount is the stocked count of the pond, Alive is the stock still in the pond
+// and the survival rate count the harvested stock as survived
+type MortalitySurvivalResponseDto struct {
+	CycleId      uint                    `json:"cycle_id"`
+	InitialCount uint                    `json:"initial_count"`
+	Deaths       uint                    `json:"deaths"`
+	Harvested    uint                    `json:"harvested"`
+	Alive        uint                    `json:"alive"`
+	SurvivalRate float64                 `json:"survival_rate"`
+	ByCause      []models.MortalityTotal `json:"by_cause"`
+}
+
+// Spike with the water quality alerts of the pond in the same period
+type MortalitySpikeDto struct {
+	mortality.Spike
+	Alerts []models.Alert `json:"alerts"`
+}
+
+type MortalitySpikeResponseDto struct {
+	CycleId     uint                `json:"cycle_id"`
+	WindowHours uint                `json:"window_hours"`
+	Threshold   float64             `json:"threshold"`
+	Spikes      []MortalitySpikeDto `json:"spikes"`
+}
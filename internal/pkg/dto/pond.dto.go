@@ -1,6 +1,9 @@
 package dto
 
 import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"gorm.io/gorm"
 )
 
@@ -9,8 +12,35 @@ type subFarm struct {
 	Name string `json:"name"`
 }
 
+// Growth of the active cycle of pond, weight is in gram and biomass is in kilogram.
+// Without any sample, the stocking is used
+type PondGrowthDto struct {
+	CycleId              uint       `json:"cycle_id"`
+	Species              string     `json:"species"`
+	StockingDate         time.Time  `json:"stocking_date"`
+	InitialCount         uint       `json:"initial_count"`
+	InitialAverageWeight float64    `json:"initial_average_weight"`
+	SampledAt            *time.Time `json:"sampled_at"`
+	DaysOfCulture        float64    `json:"days_of_culture"`
+	AverageBodyWeight    float64    `json:"average_body_weight"`
+	DailyGrowthRate      *float64   `json:"daily_growth_rate"`
+	EstimatedCount       uint       `json:"estimated_count"`
+	SurvivalRate         float64    `json:"survival_rate"`
+	Biomass              float64    `json:"biomass"`
+}
+
 type PondResponseDto struct {
 	gorm.Model
-	Name string  `json:"name"`
-	Farm subFarm `json:"farm"`
+	Name            string         `json:"name"`
+	Area            float64        `json:"area"`
+	Depth           float64        `json:"depth"`
+	Volume          float64        `json:"volume"`
+	Type            string         `json:"type"`
+	Lining          string         `json:"lining"`
+	AeratorCapacity float64        `json:"aerator_capacity"`
+	Status          string         `json:"status"`
+	Occupancy       string         `json:"occupancy"`
+	Outline         models.Polygon `json:"outline"`
+	Farm            subFarm        `json:"farm"`
+	Growth          *PondGrowthDto `json:"growth"`
 }
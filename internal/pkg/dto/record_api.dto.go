@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+// Traffic of route or status with the latency percentiles estimated from its histogram
+type RecordApiGroupResponseDto struct {
+	models.RecordApiGroup
+	AvgLatencyMs float64 `json:"avg_latency_ms"`
+	P50LatencyMs float64 `json:"p50_latency_ms"`
+	P90LatencyMs float64 `json:"p90_latency_ms"`
+	P99LatencyMs float64 `json:"p99_latency_ms"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Whether the pond can be harvested at the time, with the treatments whose withdrawal period is not over
+type HarvestClearanceResponseDto struct {
+	PondId           uint                  `json:"pond_id"`
+	At               time.Time             `json:"at"`
+	Cleared          bool                  `json:"cleared"`
+	ClearedAt        *time.Time            `json:"cleared_at"`
+	ActiveTreatments []models.TreatmentLog `json:"active_treatments"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"gorm.io/gorm"
+)
+
+type UserResponseDto struct {
+	gorm.Model
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+type TokenResponseDto struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type LoginResponseDto struct {
+	TokenResponseDto
+	User UserResponseDto `json:"user"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+type WaterQualityReadingResponseDto struct {
+	models.WaterQualityReading
+	Alerts []models.Alert `json:"alerts"`
+}
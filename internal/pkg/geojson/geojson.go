@@ -0,0 +1,104 @@
+package geojson
+
+import (
+	"strconv"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// GeoJSON object types
+const (
+	TypeFeatureCollection = "FeatureCollection"
+	TypeFeature           = "Feature"
+	TypePoint             = "Point"
+	TypePolygon           = "Polygon"
+)
+
+// Kinds of feature of farm, written in the "kind" property
+const (
+	KindFarm         = "farm"
+	KindFarmBoundary = "farm_boundary"
+	KindPond         = "pond"
+)
+
+// Geometry of GeoJSON (RFC 7946)
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// Feature of GeoJSON, geometry is null when it is unknown
+type Feature struct {
+	Type       string                 `json:"type"`
+	Id         string                 `json:"id"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// FeatureCollection of GeoJSON
+type FeatureCollection struct {
+	Type     string    `json:"type"`
+	Features []Feature `json:"features"`
+}
+
+// Func to build FeatureCollection of farm and its ponds.
+// Farm location is a Point, farm boundary and pond outline are Polygon.
+// Pond without outline is kept with null geometry so its properties are still available
+func FarmFeatureCollection(farm models.Farm) FeatureCollection {
+	collection := FeatureCollection{Type: TypeFeatureCollection, Features: []Feature{}}
+
+	farmProperties := func(kind string) map[string]interface{} {
+		return map[string]interface{}{
+			"kind":            kind,
+			"farm_id":         farm.ID,
+			"name":            farm.Name,
+			"organization_id": farm.OrganizationId,
+		}
+	}
+
+	if farm.Latitude != nil && farm.Longitude != nil {
+		collection.Features = append(collection.Features, Feature{
+			Type:       TypeFeature,
+			Id:         featureId(KindFarm, farm.ID),
+			Geometry:   &Geometry{Type: TypePoint, Coordinates: []float64{*farm.Longitude, *farm.Latitude}},
+			Properties: farmProperties(KindFarm),
+		})
+	}
+	if len(farm.Boundary) > 0 {
+		collection.Features = append(collection.Features, Feature{
+			Type:       TypeFeature,
+			Id:         featureId(KindFarmBoundary, farm.ID),
+			Geometry:   &Geometry{Type: TypePolygon, Coordinates: farm.Boundary},
+			Properties: farmProperties(KindFarmBoundary),
+		})
+	}
+
+	for _, pond := range farm.Ponds {
+		feature := Feature{
+			Type: TypeFeature,
+			Id:   featureId(KindPond, pond.ID),
+			Properties: map[string]interface{}{
+				"kind":      KindPond,
+				"farm_id":   farm.ID,
+				"pond_id":   pond.ID,
+				"name":      pond.Name,
+				"type":      pond.Type,
+				"status":    pond.Status,
+				"occupancy": pond.Occupancy,
+				"area":      pond.Area,
+				"depth":     pond.Depth,
+				"volume":    pond.EffectiveVolume(),
+			},
+		}
+		if len(pond.Outline) > 0 {
+			feature.Geometry = &Geometry{Type: TypePolygon, Coordinates: pond.Outline}
+		}
+		collection.Features = append(collection.Features, feature)
+	}
+	return collection
+}
+
+// Func to build unique id of feature, e.g. "pond-3"
+func featureId(kind string, id uint) string {
+	return kind + "-" + strconv.FormatUint(uint64(id), 10)
+}
@@ -0,0 +1,79 @@
+package harvest
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+// Yield of pond. Cycles is the number of full harvest and the average days of culture
+// is counted from stocking until the full harvest, nil when there is no full harvest
+type PondYield struct {
+	PondId               uint     `json:"pond_id"`
+	Name                 string   `json:"name"`
+	Harvests             int      `json:"harvests"`
+	Cycles               int      `json:"cycles"`
+	TotalWeight          float64  `json:"total_weight"`
+	Revenue              float64  `json:"revenue"`
+	AverageDaysOfCulture *float64 `json:"average_days_of_culture"`
+}
+
+// Yield of farm across its ponds
+type YieldReport struct {
+	FarmId               uint        `json:"farm_id"`
+	Harvests             int         `json:"harvests"`
+	Cycles               int         `json:"cycles"`
+	TotalWeight          float64     `json:"total_weight"`
+	Revenue              float64     `json:"revenue"`
+	AverageDaysOfCulture *float64    `json:"average_days_of_culture"`
+	Ponds                []PondYield `json:"ponds"`
+}
+
+// Func to aggregate the harvests of farm ponds into yield report.
+// The Cycle of every harvest must be loaded to count the days of culture
+func BuildYieldReport(farm models.Farm, harvests []models.Harvest) YieldReport {
+	report := YieldReport{FarmId: farm.ID, Ponds: make([]PondYield, 0, len(farm.Ponds))}
+	pondIndex := map[uint]int{}
+	for i, pond := range farm.Ponds {
+		pondIndex[pond.ID] = i
+		report.Ponds = append(report.Ponds, PondYield{PondId: pond.ID, Name: pond.Name})
+	}
+
+	pondDays := make([]float64, len(farm.Ponds))
+	var farmDays float64
+	for _, harvest := range harvests {
+		i, ok := pondIndex[harvest.PondId]
+		if !ok {
+			continue
+		}
+		pondYield := &report.Ponds[i]
+		pondYield.Harvests++
+		pondYield.TotalWeight += harvest.TotalWeight
+		pondYield.Revenue += harvest.Revenue()
+		if harvest.Type == models.HarvestTypeFull {
+			days := helpers.DaysOfCulture(harvest.Cycle.StockingDate, harvest.HarvestedAt)
+			pondYield.Cycles++
+			pondDays[i] += days
+			farmDays += days
+		}
+	}
+
+	for i := range report.Ponds {
+		pondYield := &report.Ponds[i]
+		report.Harvests += pondYield.Harvests
+		report.Cycles += pondYield.Cycles
+		report.TotalWeight += pondYield.TotalWeight
+		report.Revenue += pondYield.Revenue
+		pondYield.AverageDaysOfCulture = average(pondDays[i], pondYield.Cycles)
+	}
+	report.AverageDaysOfCulture = average(farmDays, report.Cycles)
+	return report
+}
+
+// Func to get the average, nil when there is nothing to average
+func average(total float64, count int) *float64 {
+	if count == 0 {
+		return nil
+	}
+	result := total / float64(count)
+	return &result
+}
@@ -0,0 +1,217 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Status of check and report
+const (
+	StatusOk   = "ok"
+	StatusFail = "fail"
+)
+
+// Time that single check can take before it is considered failed
+const DefaultTimeout = 2 * time.Second
+
+// Error when the check does not finish in time
+var ErrCheckTimeout = errors.New("check does not finish in time")
+
+var registry *Registry
+
+// Func that check single dependency, nil error means healthy
+type CheckFunc func(ctx context.Context) error
+
+// Check of dependency. Failure of optional check is reported but does not make the api unready
+type Check struct {
+	Name     string
+	Optional bool
+	Check    CheckFunc
+}
+
+// Result of single check
+type CheckResult struct {
+	Status     string  `json:"status"`
+	Optional   bool    `json:"optional"`
+	Error      string  `json:"error,omitempty"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+// Report of every check, the status is fail when any required check fail
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Func to get the report without the error text of the checks, so it can be shown to unauthenticated caller
+func (report Report) WithoutErrors() Report {
+	checks := make(map[string]CheckResult, len(report.Checks))
+	for name, result := range report.Checks {
+		result.Error = ""
+		checks[name] = result
+	}
+	return Report{Status: report.Status, Checks: checks}
+}
+
+// Registry of checks that decide the readiness of the api
+type Registry struct {
+	Timeout time.Duration
+
+	mutex  sync.Mutex
+	checks map[string]Check
+}
+
+// Func to create new Registry
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{Timeout: timeout, checks: map[string]Check{}}
+}
+
+// Func to get the Registry instance that is used by the readiness endpoint
+func GetRegistry() *Registry {
+	if registry == nil {
+		registry = NewRegistry(DefaultTimeout)
+	}
+	return registry
+}
+
+// Func to add the check, the check with the same name is replaced
+func (registry *Registry) Register(check Check) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.checks[check.Name] = check
+}
+
+// Func to remove the check by name
+func (registry *Registry) Unregister(name string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	delete(registry.checks, name)
+}
+
+// Func to get the name of registered checks, sorted
+func (registry *Registry) Names() []string {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	names := make([]string, 0, len(registry.checks))
+	for name := range registry.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Func to run every check concurrently, each is limited by the timeout of the registry
+func (registry *Registry) Run(ctx context.Context) Report {
+	registry.mutex.Lock()
+	checks := make([]Check, 0, len(registry.checks))
+	for _, check := range registry.checks {
+		checks = append(checks, check)
+	}
+	registry.mutex.Unlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = registry.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusOk, Checks: make(map[string]CheckResult, len(checks))}
+	for i, check := range checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status == StatusFail && !check.Optional {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+// Helper to run single check with timeout, the check that does not respect the context is abandoned
+func (registry *Registry) run(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, registry.Timeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("check panic: %v", recovered)
+			}
+		}()
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ErrCheckTimeout
+	}
+
+	result := CheckResult{
+		Status:     StatusOk,
+		Optional:   check.Optional,
+		DurationMs: float64(time.Since(startedAt)) / float64(time.Millisecond),
+	}
+	if err != nil {
+		result.Status = StatusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Func to create check that reuse the result of the check for the ttl,
+// so slow or rate limited dependency is not called on every probe
+func CachedCheck(check CheckFunc, ttl time.Duration) CheckFunc {
+	var (
+		mutex     sync.Mutex
+		checkedAt time.Time
+		lastErr   error
+	)
+	return func(ctx context.Context) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if !checkedAt.IsZero() && time.Since(checkedAt) < ttl {
+			return lastErr
+		}
+		lastErr = check(ctx)
+		checkedAt = time.Now()
+		return lastErr
+	}
+}
+
+// Func to create check that GET the url and expect 2xx status, with basic auth when username is not empty
+func HttpGetCheck(client *http.Client, url string, username string, password string) CheckFunc {
+	return func(ctx context.Context) error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		if username != "" {
+			request.SetBasicAuth(username, password)
+		}
+
+		response, err := client.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		if response.StatusCode < 200 || response.StatusCode > 299 {
+			return fmt.Errorf("unexpected status %d", response.StatusCode)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+var (
+	metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+	labelNamePattern  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	helpReplacer       = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// Func to write the metric families in the text exposition format
+// Reference --> https://prometheus.io/docs/instrumenting/exposition_formats/#text-based-format
+func WriteText(w io.Writer, families []Family) error {
+	writer := bufio.NewWriter(w)
+	for _, family := range families {
+		if !metricNamePattern.MatchString(family.Name) {
+			return fmt.Errorf("invalid metric name %q", family.Name)
+		}
+
+		fmt.Fprintf(writer, "# HELP %s %s\n", family.Name, helpReplacer.Replace(family.Help))
+		fmt.Fprintf(writer, "# TYPE %s %s\n", family.Name, family.Type)
+		for _, sample := range family.Samples {
+			if err := writeSample(writer, sample); err != nil {
+				return err
+			}
+		}
+	}
+	return writer.Flush()
+}
+
+// Helper to write single sample line, name{label="value",...} value
+func writeSample(writer *bufio.Writer, sample Sample) error {
+	if !metricNamePattern.MatchString(sample.Name) {
+		return fmt.Errorf("invalid metric name %q", sample.Name)
+	}
+
+	writer.WriteString(sample.Name)
+	if len(sample.Labels) > 0 {
+		writer.WriteByte('{')
+		for i, label := range sample.Labels {
+			if !labelNamePattern.MatchString(label.Name) || strings.HasPrefix(label.Name, "__") {
+				return fmt.Errorf("invalid label name %q of metric %s", label.Name, sample.Name)
+			}
+			if i > 0 {
+				writer.WriteByte(',')
+			}
+			fmt.Fprintf(writer, `%s="%s"`, label.Name, labelValueReplacer.Replace(label.Value))
+		}
+		writer.WriteByte('}')
+	}
+	writer.WriteByte(' ')
+	writer.WriteString(formatFloat(sample.Value))
+	writer.WriteByte('\n')
+	return nil
+}
+
+// Helper to format the value, infinity and NaN are spelled as in the format
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
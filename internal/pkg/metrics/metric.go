@@ -0,0 +1,250 @@
+package metrics
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Type of metric family in the exposition format
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// Default buckets of request duration histogram, in seconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Label of sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Single value of metric family, the name include the suffix of histogram (_bucket, _sum and _count)
+type Sample struct {
+	Name   string
+	Labels []Label
+	Value  float64
+}
+
+// Metric family with its samples
+type Family struct {
+	Name    string
+	Help    string
+	Type    string
+	Samples []Sample
+}
+
+// Collector of metric families, called on every scrape
+type Collector interface {
+	Collect() []Family
+}
+
+// Adapter to use func as Collector
+type CollectorFunc func() []Family
+
+// Func to call the func
+func (collect CollectorFunc) Collect() []Family {
+	return collect()
+}
+
+// Values of metric per label values, shared by counter, gauge and histogram
+type vec struct {
+	name       string
+	help       string
+	labelNames []string
+	mutex      sync.Mutex
+	values     map[string][]string
+}
+
+// Helper to get the key of the label values, the values are registered on the first use
+func (v *vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metric %s expect %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	if _, ok := v.values[key]; !ok {
+		v.values[key] = append([]string{}, labelValues...)
+	}
+	return key
+}
+
+// Helper to get the keys in order, so the output is stable between scrapes
+func (v *vec) sortedKeys() []string {
+	keys := make([]string, 0, len(v.values))
+	for key := range v.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Helper to build the labels of the label values
+func (v *vec) labels(key string, extra ...Label) []Label {
+	labels := make([]Label, 0, len(v.labelNames)+len(extra))
+	for i, name := range v.labelNames {
+		labels = append(labels, Label{Name: name, Value: v.values[key][i]})
+	}
+	return append(labels, extra...)
+}
+
+// Counter that only goes up, per label values
+type CounterVec struct {
+	vec
+	counts map[string]float64
+}
+
+// Func to create new CounterVec
+func NewCounterVec(name string, help string, labelNames ...string) *CounterVec {
+	return &CounterVec{
+		vec:    vec{name: name, help: help, labelNames: labelNames, values: map[string][]string{}},
+		counts: map[string]float64{},
+	}
+}
+
+// Func to add one to the counter of label values
+func (counter *CounterVec) Inc(labelValues ...string) {
+	counter.Add(1, labelValues...)
+}
+
+// Func to add the value to the counter of label values, negative value is ignored
+func (counter *CounterVec) Add(value float64, labelValues ...string) {
+	if value < 0 {
+		return
+	}
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	counter.counts[counter.key(labelValues)] += value
+}
+
+// Func to collect the counter
+func (counter *CounterVec) Collect() []Family {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	family := Family{Name: counter.name, Help: counter.help, Type: TypeCounter}
+	for _, key := range counter.sortedKeys() {
+		family.Samples = append(family.Samples, Sample{Name: counter.name, Labels: counter.labels(key), Value: counter.counts[key]})
+	}
+	return []Family{family}
+}
+
+// Gauge that goes up and down, per label values
+type GaugeVec struct {
+	vec
+	gauges map[string]float64
+}
+
+// Func to create new GaugeVec
+func NewGaugeVec(name string, help string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{
+		vec:    vec{name: name, help: help, labelNames: labelNames, values: map[string][]string{}},
+		gauges: map[string]float64{},
+	}
+}
+
+// Func to set the gauge of label values
+func (gauge *GaugeVec) Set(value float64, labelValues ...string) {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+	gauge.gauges[gauge.key(labelValues)] = value
+}
+
+// Func to add the value (can be negative) to the gauge of label values
+func (gauge *GaugeVec) Add(value float64, labelValues ...string) {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+	gauge.gauges[gauge.key(labelValues)] += value
+}
+
+// Func to add one to the gauge of label values
+func (gauge *GaugeVec) Inc(labelValues ...string) {
+	gauge.Add(1, labelValues...)
+}
+
+// Func to subtract one from the gauge of label values
+func (gauge *GaugeVec) Dec(labelValues ...string) {
+	gauge.Add(-1, labelValues...)
+}
+
+// Func to collect the gauge
+func (gauge *GaugeVec) Collect() []Family {
+	gauge.mutex.Lock()
+	defer gauge.mutex.Unlock()
+
+	family := Family{Name: gauge.name, Help: gauge.help, Type: TypeGauge}
+	for _, key := range gauge.sortedKeys() {
+		family.Samples = append(family.Samples, Sample{Name: gauge.name, Labels: gauge.labels(key), Value: gauge.gauges[key]})
+	}
+	return []Family{family}
+}
+
+// Histogram of observed values, per label values
+type HistogramVec struct {
+	vec
+	buckets    []float64
+	histograms map[string]*histogram
+}
+
+// Count per bucket (not cumulative, the last one is above every bucket), sum and count of observed values
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// Func to create new HistogramVec, the buckets are the upper bounds in increasing order
+func NewHistogramVec(name string, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	return &HistogramVec{
+		vec:        vec{name: name, help: help, labelNames: labelNames, values: map[string][]string{}},
+		buckets:    buckets,
+		histograms: map[string]*histogram{},
+	}
+}
+
+// Func to observe the value in the histogram of label values
+func (histogramVec *HistogramVec) Observe(value float64, labelValues ...string) {
+	histogramVec.mutex.Lock()
+	defer histogramVec.mutex.Unlock()
+
+	key := histogramVec.key(labelValues)
+	observed, ok := histogramVec.histograms[key]
+	if !ok {
+		observed = &histogram{counts: make([]uint64, len(histogramVec.buckets)+1)}
+		histogramVec.histograms[key] = observed
+	}
+
+	index := sort.SearchFloat64s(histogramVec.buckets, value)
+	observed.counts[index]++
+	observed.sum += value
+	observed.count++
+}
+
+// Func to collect the histogram, the buckets are cumulative in the exposition format
+func (histogramVec *HistogramVec) Collect() []Family {
+	histogramVec.mutex.Lock()
+	defer histogramVec.mutex.Unlock()
+
+	family := Family{Name: histogramVec.name, Help: histogramVec.help, Type: TypeHistogram}
+	for _, key := range histogramVec.sortedKeys() {
+		observed := histogramVec.histograms[key]
+		var cumulative uint64
+		for i, bound := range histogramVec.buckets {
+			cumulative += observed.counts[i]
+			family.Samples = append(family.Samples, Sample{
+				Name:   histogramVec.name + "_bucket",
+				Labels: histogramVec.labels(key, Label{Name: "le", Value: formatFloat(bound)}),
+				Value:  float64(cumulative),
+			})
+		}
+		family.Samples = append(family.Samples,
+			Sample{Name: histogramVec.name + "_bucket", Labels: histogramVec.labels(key, Label{Name: "le", Value: "+Inf"}), Value: float64(observed.count)},
+			Sample{Name: histogramVec.name + "_sum", Labels: histogramVec.labels(key), Value: observed.sum},
+			Sample{Name: histogramVec.name + "_count", Labels: histogramVec.labels(key), Value: float64(observed.count)},
+		)
+	}
+	return []Family{family}
+}
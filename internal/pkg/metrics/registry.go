@@ -0,0 +1,99 @@
+package metrics
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+var registry *Registry
+
+// Registry of collectors that are exposed together
+type Registry struct {
+	mutex      sync.Mutex
+	collectors []Collector
+}
+
+// Func to create new Registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Func to get the Registry instance that is exposed by the api
+func GetRegistry() *Registry {
+	if registry == nil {
+		registry = NewRegistry()
+	}
+	return registry
+}
+
+// Func to add the collectors to the registry
+func (registry *Registry) Register(collectors ...Collector) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.collectors = append(registry.collectors, collectors...)
+}
+
+// Func to collect every family of the collectors, sorted by name
+func (registry *Registry) Gather() ([]Family, error) {
+	registry.mutex.Lock()
+	collectors := append([]Collector{}, registry.collectors...)
+	registry.mutex.Unlock()
+
+	var families []Family
+	names := map[string]bool{}
+	for _, collector := range collectors {
+		for _, family := range collector.Collect() {
+			if names[family.Name] {
+				return nil, fmt.Errorf("metric %s is collected more than once", family.Name)
+			}
+			names[family.Name] = true
+			families = append(families, family)
+		}
+	}
+
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].Name < families[j].Name
+	})
+	return families, nil
+}
+
+// Func to write every family of the collectors in the text exposition format
+func (registry *Registry) WriteText(w io.Writer) error {
+	families, err := registry.Gather()
+	if err != nil {
+		return err
+	}
+	return WriteText(w, families)
+}
+
+// Func to create Collector of the connection pool statistics of database.
+// When the statistics can not be taken, nothing is collected
+func NewDBStatsCollector(stats func() (sql.DBStats, error)) Collector {
+	return CollectorFunc(func() []Family {
+		dbStats, err := stats()
+		if err != nil {
+			return nil
+		}
+
+		gauge := func(name, help string, value float64) Family {
+			return Family{Name: name, Help: help, Type: TypeGauge, Samples: []Sample{{Name: name, Value: value}}}
+		}
+		counter := func(name, help string, value float64) Family {
+			return Family{Name: name, Help: help, Type: TypeCounter, Samples: []Sample{{Name: name, Value: value}}}
+		}
+		return []Family{
+			gauge("db_max_open_connections", "Maximum number of open connections to the database.", float64(dbStats.MaxOpenConnections)),
+			gauge("db_open_connections", "Number of established connections, both in use and idle.", float64(dbStats.OpenConnections)),
+			gauge("db_in_use_connections", "Number of connections currently in use.", float64(dbStats.InUse)),
+			gauge("db_idle_connections", "Number of idle connections.", float64(dbStats.Idle)),
+			counter("db_wait_count_total", "Total number of connections waited for.", float64(dbStats.WaitCount)),
+			counter("db_wait_duration_seconds_total", "Total time blocked waiting for a new connection.", dbStats.WaitDuration.Seconds()),
+			counter("db_max_idle_closed_total", "Total number of connections closed due to the maximum idle connections.", float64(dbStats.MaxIdleClosed)),
+			counter("db_max_idle_time_closed_total", "Total number of connections closed due to the maximum idle time.", float64(dbStats.MaxIdleTimeClosed)),
+			counter("db_max_lifetime_closed_total", "Total number of connections closed due to the maximum lifetime.", float64(dbStats.MaxLifetimeClosed)),
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Alert severities
+const (
+	AlertSeverityWarning  = "warning"
+	AlertSeverityCritical = "critical"
+)
+
+// Alert statuses
+const (
+	AlertStatusOpen         = "open"
+	AlertStatusAcknowledged = "acknowledged"
+	AlertStatusResolved     = "resolved"
+)
+
+// Struct for Alert Models.
+// Raised when water quality reading or device measurement is out of the pond threshold,
+// the reading id is 0 when it is raised by measurement.
+// While it is not resolved, the next out of range reading of the same parameter is merged into it
+type Alert struct {
+	gorm.Model
+	PondId         uint       `gorm:"index" json:"pond_id"`
+	Pond           Pond       `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	ReadingId      uint       `json:"reading_id"`
+	MeasurementId  *uint      `json:"measurement_id"`
+	Parameter      string     `gorm:"type:varchar(30)" json:"parameter"`
+	Value          float64    `json:"value"`
+	Min            *float64   `json:"min"`
+	Max            *float64   `json:"max"`
+	Severity       string     `gorm:"type:varchar(20);index" json:"severity"`
+	Status         string     `gorm:"type:varchar(20);index" json:"status"`
+	Occurrences    uint       `json:"occurrences"`
+	AcknowledgedAt *time.Time `json:"acknowledged_at"`
+	AcknowledgedBy *uint      `json:"acknowledged_by"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	ResolvedBy     *uint      `json:"resolved_by"`
+}
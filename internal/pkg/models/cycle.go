@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Cycle statuses
+const (
+	CycleStatusPlanned   = "planned"
+	CycleStatusActive    = "active"
+	CycleStatusHarvested = "harvested"
+	CycleStatusFailed    = "failed"
+)
+
+// Struct for Cycle Models (stocking / crop cycle of pond).
+// InitialAverageWeight is in gram
+type Cycle struct {
+	gorm.Model
+	PondId               uint       `gorm:"index" json:"pond_id"`
+	Pond                 Pond       `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Species              string     `gorm:"type:varchar(100)" json:"species"`
+	StockingDate         time.Time  `json:"stocking_date"`
+	InitialCount         uint       `json:"initial_count"`
+	InitialAverageWeight float64    `json:"initial_average_weight"`
+	Status               string     `gorm:"type:varchar(20);index" json:"status"`
+	EndedAt              *time.Time `json:"ended_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Device Models.
+// Device is IoT probe registered to pond, only the hash of its api key is stored
+type Device struct {
+	gorm.Model
+	PondId     uint       `gorm:"index" json:"pond_id"`
+	Pond       Pond       `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Name       string     `gorm:"type:varchar(255)" json:"name"`
+	ApiKeyHash string     `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
@@ -2,9 +2,15 @@ package models
 
 import "gorm.io/gorm"
 
-// Struct for Farm Models
+// Struct for Farm Models.
+// Location and boundary are optional
 type Farm struct {
 	gorm.Model
-	Name  string `gorm:"type:varchar(100)" json:"name"`
-	Ponds []Pond `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"ponds"`
+	Name           string       `gorm:"type:varchar(100)" json:"name"`
+	Latitude       *float64     `gorm:"index:idx_farm_location" json:"latitude"`
+	Longitude      *float64     `gorm:"index:idx_farm_location" json:"longitude"`
+	Boundary       Polygon      `gorm:"type:text" json:"boundary"`
+	OrganizationId uint         `gorm:"index" json:"organization_id"`
+	Organization   Organization `gorm:"foreignkey:OrganizationId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Ponds          []Pond       `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"ponds"`
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Feeding Log Models, quantity is in kilogram
+type FeedingLog struct {
+	gorm.Model
+	PondId   uint      `gorm:"index:idx_feeding_pond_fed_at" json:"pond_id"`
+	Pond     Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	FeedType string    `gorm:"type:varchar(100)" json:"feed_type"`
+	Quantity float64   `json:"quantity"`
+	FedAt    time.Time `gorm:"index:idx_feeding_pond_fed_at" json:"fed_at"`
+}
+
+// Total of feed given to pond per feed type
+type FeedingTotal struct {
+	FeedType string  `json:"feed_type"`
+	Quantity float64 `json:"quantity"`
+	Feedings int64   `json:"feedings"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Growth Sample Models, the sample netted from the pond during a cycle.
+// TotalWeight is in gram, EstimatedCount is the estimated living population when sampling
+type GrowthSample struct {
+	gorm.Model
+	PondId         uint      `gorm:"index" json:"pond_id"`
+	Pond           Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	CycleId        uint      `gorm:"index:idx_sample_cycle_sampled_at" json:"cycle_id"`
+	Cycle          Cycle     `gorm:"foreignkey:CycleId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	SampledAt      time.Time `gorm:"index:idx_sample_cycle_sampled_at" json:"sampled_at"`
+	SampleCount    uint      `json:"sample_count"`
+	TotalWeight    float64   `json:"total_weight"`
+	EstimatedCount *uint     `json:"estimated_count"`
+}
+
+// Func to get the average body weight (gram) of the sample
+func (sample GrowthSample) AverageBodyWeight() float64 {
+	if sample.SampleCount == 0 {
+		return 0
+	}
+	return sample.TotalWeight / float64(sample.SampleCount)
+}
@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Harvest types, full harvest end the cycle and mark the pond as empty
+const (
+	HarvestTypePartial = "partial"
+	HarvestTypeFull    = "full"
+)
+
+// Struct for Harvest Models.
+// TotalWeight is in kilogram and PricePerKg is the sale price of a kilogram
+type Harvest struct {
+	gorm.Model
+	PondId      uint      `gorm:"index:idx_harvest_pond_harvested_at" json:"pond_id"`
+	Pond        Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	CycleId     uint      `gorm:"index" json:"cycle_id"`
+	Cycle       Cycle     `gorm:"foreignkey:CycleId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Type        string    `gorm:"type:varchar(20)" json:"type"`
+	HarvestedAt time.Time `gorm:"index:idx_harvest_pond_harvested_at" json:"harvested_at"`
+	Quantity    uint      `json:"quantity"`
+	TotalWeight float64   `json:"total_weight"`
+	SizeGrade   string    `gorm:"type:varchar(30)" json:"size_grade"`
+	PricePerKg  float64   `json:"price_per_kg"`
+}
+
+// Func to get the revenue of the harvest
+func (harvest Harvest) Revenue() float64 {
+	return harvest.TotalWeight * harvest.PricePerKg
+}
@@ -0,0 +1,30 @@
+package models
+
+import "gorm.io/gorm"
+
+// Inventory item categories
+const (
+	InventoryCategoryFeed      = "feed"
+	InventoryCategoryLime      = "lime"
+	InventoryCategoryProbiotic = "probiotic"
+	InventoryCategoryMedicine  = "medicine"
+	InventoryCategoryOther     = "other"
+)
+
+// Struct for Inventory Item Models.
+// The on-hand balance is computed from its stock movements,
+// LowStockThreshold is in the same unit and nil means it is never low on stock
+type InventoryItem struct {
+	gorm.Model
+	FarmId            uint     `gorm:"index" json:"farm_id"`
+	Farm              Farm     `gorm:"foreignkey:FarmId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Name              string   `gorm:"type:varchar(255)" json:"name"`
+	Category          string   `gorm:"type:varchar(20)" json:"category"`
+	Unit              string   `gorm:"type:varchar(20)" json:"unit"`
+	LowStockThreshold *float64 `json:"low_stock_threshold"`
+}
+
+// Func to check whether the on-hand balance is at or below the low stock threshold
+func (item InventoryItem) IsLowStock(onHand float64) bool {
+	return item.LowStockThreshold != nil && onHand <= *item.LowStockThreshold
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Struct for Measurement Models.
+// Measurement is telemetry reading posted by device, it is not soft deleted
+// and it is unique on device and timestamp so resent batch is not stored twice
+type Measurement struct {
+	ID              uint      `gorm:"primarykey" json:"id"`
+	PondId          uint      `gorm:"index:idx_measurement_pond_measured_at" json:"pond_id"`
+	Pond            Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	DeviceId        uint      `gorm:"uniqueIndex:idx_measurement_device_measured_at" json:"device_id"`
+	Device          Device    `gorm:"foreignkey:DeviceId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	MeasuredAt      time.Time `gorm:"index:idx_measurement_pond_measured_at;uniqueIndex:idx_measurement_device_measured_at" json:"measured_at"`
+	DissolvedOxygen *float64  `json:"dissolved_oxygen"`
+	Ph              *float64  `json:"ph"`
+	Temperature     *float64  `json:"temperature"`
+	Salinity        *float64  `json:"salinity"`
+	Ammonia         *float64  `json:"ammonia"`
+	Nitrite         *float64  `json:"nitrite"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Func to get the measured parameters of the measurement
+func (measurement Measurement) Measurements() map[string]float64 {
+	reading := WaterQualityReading{
+		DissolvedOxygen: measurement.DissolvedOxygen,
+		Ph:              measurement.Ph,
+		Temperature:     measurement.Temperature,
+		Salinity:        measurement.Salinity,
+		Ammonia:         measurement.Ammonia,
+		Nitrite:         measurement.Nitrite,
+	}
+	return reading.Measurements()
+}
+
+// Func to set the parameter of the measurement by its name,
+// return false if the parameter is unknown
+func (measurement *Measurement) SetParameter(parameter string, value float64) bool {
+	switch parameter {
+	case ParameterDissolvedOxygen:
+		measurement.DissolvedOxygen = &value
+	case ParameterPh:
+		measurement.Ph = &value
+	case ParameterTemperature:
+		measurement.Temperature = &value
+	case ParameterSalinity:
+		measurement.Salinity = &value
+	case ParameterAmmonia:
+		measurement.Ammonia = &value
+	case ParameterNitrite:
+		measurement.Nitrite = &value
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Mortality Log Models, the dead stock found in the pond during a cycle
+type MortalityLog struct {
+	gorm.Model
+	PondId     uint      `gorm:"index" json:"pond_id"`
+	Pond       Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	CycleId    uint      `gorm:"index:idx_mortality_cycle_recorded_at" json:"cycle_id"`
+	Cycle      Cycle     `gorm:"foreignkey:CycleId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Count      uint      `json:"count"`
+	Cause      string    `gorm:"type:varchar(100)" json:"cause"`
+	RecordedAt time.Time `gorm:"index:idx_mortality_cycle_recorded_at" json:"recorded_at"`
+}
+
+// Total of dead stock of cycle per suspected cause
+type MortalityTotal struct {
+	Cause string `json:"cause"`
+	Count uint   `json:"count"`
+}
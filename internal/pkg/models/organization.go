@@ -0,0 +1,11 @@
+package models
+
+import "gorm.io/gorm"
+
+// Struct for Organization Models (tenant)
+type Organization struct {
+	gorm.Model
+	Name  string `gorm:"type:varchar(100)" json:"name"`
+	Users []User `gorm:"many2many:user_organizations;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	Farms []Farm `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
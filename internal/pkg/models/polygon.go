@@ -0,0 +1,57 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Polygon is the coordinates of GeoJSON polygon, rings of [longitude, latitude] position.
+// The first ring is the outline and the rest are holes. It is stored as JSON text
+type Polygon [][][]float64
+
+// Func to validate the polygon, every ring must be closed with at least 4 valid positions
+func (polygon Polygon) Validate() error {
+	for i, ring := range polygon {
+		if len(ring) < 4 {
+			return fmt.Errorf("ring %d of polygon must have at least 4 positions", i)
+		}
+		for _, position := range ring {
+			if len(position) != 2 {
+				return fmt.Errorf("position of ring %d must be [longitude, latitude]", i)
+			}
+			if position[0] < -180 || position[0] > 180 || position[1] < -90 || position[1] > 90 {
+				return fmt.Errorf("position of ring %d is out of range", i)
+			}
+		}
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return fmt.Errorf("ring %d of polygon must be closed", i)
+		}
+	}
+	return nil
+}
+
+// Func to convert polygon into database value
+func (polygon Polygon) Value() (driver.Value, error) {
+	if polygon == nil {
+		return nil, nil
+	}
+	value, err := json.Marshal(polygon)
+	return string(value), err
+}
+
+// Func to read polygon from database value
+func (polygon *Polygon) Scan(value interface{}) error {
+	switch data := value.(type) {
+	case nil:
+		*polygon = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(data, polygon)
+	case string:
+		return json.Unmarshal([]byte(data), polygon)
+	}
+	return errors.New("failed to scan polygon")
+}
@@ -2,10 +2,68 @@ package models
 
 import "gorm.io/gorm"
 
-// Struct for Pond Models
+// Pond types
+const (
+	PondTypeEarthen = "earthen"
+	PondTypeLined   = "lined"
+	PondTypeTank    = "tank"
+	PondTypeRas     = "ras"
+)
+
+// Pond statuses, only active pond can be stocked
+const (
+	PondStatusActive      = "active"
+	PondStatusDrying      = "drying"
+	PondStatusMaintenance = "maintenance"
+)
+
+// Pond occupancies, pond is stocked while it has active cycle
+const (
+	PondOccupancyEmpty   = "empty"
+	PondOccupancyStocked = "stocked"
+)
+
+// Struct for Pond Models.
+// Area is in m², Depth is in m, Volume is in m³ and AeratorCapacity is in horsepower
 type Pond struct {
 	gorm.Model
-	Name   string `gorm:"type:varchar(100)" json:"name"`
-	FarmId uint   `json:"-"`
-	Farm   Farm   `gorm:"foreignkey:FarmId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"farm"`
+	Name            string  `gorm:"type:varchar(100)" json:"name"`
+	Area            float64 `json:"area"`
+	Depth           float64 `json:"depth"`
+	Volume          float64 `json:"volume"`
+	Type            string  `gorm:"type:varchar(20);default:earthen" json:"type"`
+	Lining          string  `gorm:"type:varchar(50)" json:"lining"`
+	AeratorCapacity float64 `json:"aerator_capacity"`
+	Status          string  `gorm:"type:varchar(20);default:active" json:"status"`
+	Occupancy       string  `gorm:"type:varchar(20);default:empty" json:"occupancy"`
+	Outline         Polygon `gorm:"type:text" json:"outline"`
+	FarmId          uint    `json:"-"`
+	Farm            Farm    `gorm:"foreignkey:FarmId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"farm"`
+}
+
+// Func to get the volume of pond, computed from area and depth when it is not set
+func (pond Pond) EffectiveVolume() float64 {
+	if pond.Volume > 0 {
+		return pond.Volume
+	}
+	return pond.Area * pond.Depth
+}
+
+// Func to check whether the attributes that the stocking density is measured by differ from the other pond
+func (pond Pond) StockingDensityChanged(other Pond) bool {
+	return pond.Type != other.Type || pond.Area != other.Area || pond.EffectiveVolume() != other.EffectiveVolume()
+}
+
+// Func to get the stocking density of the count in pond.
+// Earthen and lined pond is measured per m², tank and RAS per m³.
+// False is returned when the dimension of the pond is unknown
+func (pond Pond) StockingDensity(count uint) (float64, bool) {
+	size := pond.Area
+	if pond.Type == PondTypeTank || pond.Type == PondTypeRas {
+		size = pond.EffectiveVolume()
+	}
+	if size <= 0 {
+		return 0, false
+	}
+	return float64(count) / size, true
 }
@@ -0,0 +1,17 @@
+package models
+
+import "gorm.io/gorm"
+
+// Struct for Pond Threshold Models (safe range of water quality parameter).
+// Value outside [Min, Max] raise warning, outside [CriticalMin, CriticalMax] raise critical.
+// Nil bound means no bound
+type PondThreshold struct {
+	gorm.Model
+	PondId      uint     `gorm:"uniqueIndex:idx_threshold_pond_parameter" json:"pond_id"`
+	Pond        Pond     `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Parameter   string   `gorm:"type:varchar(30);uniqueIndex:idx_threshold_pond_parameter" json:"parameter"`
+	Min         *float64 `json:"min"`
+	Max         *float64 `json:"max"`
+	CriticalMin *float64 `json:"critical_min"`
+	CriticalMax *float64 `json:"critical_max"`
+}
@@ -1,20 +1,119 @@
 package models
 
 import (
+	"net/http"
 	"time"
 
 	"gorm.io/gorm"
 )
 
-// Record Api Model for record the traffic
+// Route of request that does not match any registered route
+const RecordApiUnmatchedRoute = "unmatched"
+
+// Method of request that is not a standard http method
+const RecordApiOtherMethod = "OTHER"
+
+// Standard http methods, the others are recorded as RecordApiOtherMethod
+var recordApiMethods = map[string]bool{
+	http.MethodGet: true, http.MethodHead: true, http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true,
+	http.MethodDelete: true, http.MethodConnect: true, http.MethodOptions: true, http.MethodTrace: true,
+}
+
+// Upper bounds of latency histogram bucket, in milliseconds. The last bucket has no upper bound
+var LatencyBoundsMs = []float64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000}
+
+// Columns of latency histogram bucket, in the order of the bounds
+var LatencyHistogramColumns = []string{
+	"latency_le_5ms", "latency_le_10ms", "latency_le_25ms", "latency_le_50ms", "latency_le_100ms", "latency_le_250ms",
+	"latency_le_500ms", "latency_le_1000ms", "latency_le_2500ms", "latency_le_5000ms", "latency_le_10000ms", "latency_le_inf",
+}
+
+// Record Api Model for record the traffic, one row per hour of method, route, status and user agent
 type RecordApi struct {
-	ID          uint           `gorm:"primarykey" json:"-"`
-	CreatedAt   time.Time      `json:"-"`
-	UpdatedAt   time.Time      `json:"-"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
-	RequestPath string         `json:"request_path"`
-	UserAgent   string         `json:"user_agent"`
-	Status      int            `json:"status"`
-	Referer     string         `json:"referer"`
-	Count       uint           `json:"count"`
+	ID               uint             `gorm:"primarykey" json:"-"`
+	CreatedAt        time.Time        `json:"-"`
+	UpdatedAt        time.Time        `json:"-"`
+	DeletedAt        gorm.DeletedAt   `gorm:"index" json:"-"`
+	BucketStart      time.Time        `gorm:"uniqueIndex:idx_record_api_bucket" json:"bucket_start"`
+	Method           string           `gorm:"type:varchar(10);uniqueIndex:idx_record_api_bucket" json:"method"`
+	Route            string           `gorm:"type:varchar(255);uniqueIndex:idx_record_api_bucket" json:"route"`
+	Status           int              `gorm:"uniqueIndex:idx_record_api_bucket" json:"status"`
+	UserAgent        string           `gorm:"type:varchar(255);uniqueIndex:idx_record_api_bucket" json:"user_agent"`
+	Count            uint             `json:"count"`
+	LatencySumMs     float64          `json:"latency_sum_ms"`
+	LatencyMaxMs     float64          `json:"latency_max_ms"`
+	LatencyHistogram LatencyHistogram `gorm:"embedded" json:"latency_histogram"`
+}
+
+// Count of request per latency bucket, the latency above the previous bound up to the bound of the bucket
+type LatencyHistogram struct {
+	Le5ms     uint `gorm:"column:latency_le_5ms" json:"le_5ms"`
+	Le10ms    uint `gorm:"column:latency_le_10ms" json:"le_10ms"`
+	Le25ms    uint `gorm:"column:latency_le_25ms" json:"le_25ms"`
+	Le50ms    uint `gorm:"column:latency_le_50ms" json:"le_50ms"`
+	Le100ms   uint `gorm:"column:latency_le_100ms" json:"le_100ms"`
+	Le250ms   uint `gorm:"column:latency_le_250ms" json:"le_250ms"`
+	Le500ms   uint `gorm:"column:latency_le_500ms" json:"le_500ms"`
+	Le1000ms  uint `gorm:"column:latency_le_1000ms" json:"le_1000ms"`
+	Le2500ms  uint `gorm:"column:latency_le_2500ms" json:"le_2500ms"`
+	Le5000ms  uint `gorm:"column:latency_le_5000ms" json:"le_5000ms"`
+	Le10000ms uint `gorm:"column:latency_le_10000ms" json:"le_10000ms"`
+	LeInf     uint `gorm:"column:latency_le_inf" json:"le_inf"`
+}
+
+// Func to map the method of request to the standard http methods,
+// so custom method does not overflow the column nor make a row per method
+func NormalizeMethod(method string) string {
+	if recordApiMethods[method] {
+		return method
+	}
+	return RecordApiOtherMethod
+}
+
+// Func to get the count of buckets, in the order of the bounds
+func (histogram LatencyHistogram) Counts() []uint {
+	counts := make([]uint, 0, len(LatencyHistogramColumns))
+	for _, count := range histogram.buckets() {
+		counts = append(counts, *count)
+	}
+	return counts
+}
+
+// Func to count the latency in its bucket
+func (histogram *LatencyHistogram) Observe(latencyMs float64) {
+	buckets := histogram.buckets()
+	for i, bound := range LatencyBoundsMs {
+		if latencyMs <= bound {
+			*buckets[i]++
+			return
+		}
+	}
+	*buckets[len(buckets)-1]++
+}
+
+// Func to add the counts of other histogram
+func (histogram *LatencyHistogram) Add(other LatencyHistogram) {
+	otherCounts := other.Counts()
+	for i, count := range histogram.buckets() {
+		*count += otherCounts[i]
+	}
+}
+
+// Helper to get the buckets in the order of the bounds
+func (histogram *LatencyHistogram) buckets() []*uint {
+	return []*uint{
+		&histogram.Le5ms, &histogram.Le10ms, &histogram.Le25ms, &histogram.Le50ms, &histogram.Le100ms, &histogram.Le250ms,
+		&histogram.Le500ms, &histogram.Le1000ms, &histogram.Le2500ms, &histogram.Le5000ms, &histogram.Le10000ms, &histogram.LeInf,
+	}
+}
+
+// Traffic of route (with its method) or status summed over the time range
+type RecordApiGroup struct {
+	Method           string           `json:"method,omitempty"`
+	Route            string           `json:"route,omitempty"`
+	Status           int              `json:"status,omitempty"`
+	Count            uint             `json:"count"`
+	LatencySumMs     float64          `json:"latency_sum_ms"`
+	LatencyMaxMs     float64          `json:"latency_max_ms"`
+	LatencyHistogram LatencyHistogram `gorm:"embedded" json:"latency_histogram"`
 }
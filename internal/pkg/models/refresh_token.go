@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Refresh Token Models.
+// Only the hash of the token is stored
+type RefreshToken struct {
+	gorm.Model
+	UserId    uint       `gorm:"index" json:"-"`
+	User      User       `gorm:"foreignkey:UserId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	TokenHash string     `gorm:"type:varchar(64);uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
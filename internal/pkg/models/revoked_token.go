@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Revoked Token Models.
+// Revocation list of access token (by jti) that is not expired yet
+type RevokedToken struct {
+	gorm.Model
+	Jti       string    `gorm:"type:varchar(64);uniqueIndex" json:"jti"`
+	ExpiresAt time.Time `gorm:"index" json:"expires_at"`
+}
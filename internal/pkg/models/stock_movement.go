@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Stock movement types
+const (
+	StockMovementPurchase    = "purchase"
+	StockMovementConsumption = "consumption"
+	StockMovementAdjustment  = "adjustment"
+)
+
+// Struct for Stock Movement Models.
+// Quantity is signed, purchase add to the stock and consumption take from it,
+// PondId is the pond that consume the item (optional)
+type StockMovement struct {
+	gorm.Model
+	ItemId   uint          `gorm:"index:idx_stock_movement_item_moved_at" json:"item_id"`
+	Item     InventoryItem `gorm:"foreignkey:ItemId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Type     string        `gorm:"type:varchar(20)" json:"type"`
+	Quantity float64       `json:"quantity"`
+	PondId   *uint         `gorm:"index" json:"pond_id"`
+	Pond     *Pond         `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:SET NULL" json:"-"`
+	UnitCost *float64      `json:"unit_cost"`
+	Note     string        `gorm:"type:varchar(255)" json:"note"`
+	MovedAt  time.Time     `gorm:"index:idx_stock_movement_item_moved_at" json:"moved_at"`
+}
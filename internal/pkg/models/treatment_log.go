@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Longest withdrawal period of treatment that is accepted, in days
+const MaxWithdrawalDays = 365
+
+// Struct for Treatment Log Models, antibiotic or chemical given to the pond.
+// The pond must not be harvested until the withdrawal period after the treatment is over
+type TreatmentLog struct {
+	gorm.Model
+	PondId         uint      `gorm:"index:idx_treatment_pond_treated_at" json:"pond_id"`
+	Pond           Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	Product        string    `gorm:"type:varchar(255)" json:"product"`
+	Dose           float64   `json:"dose"`
+	DoseUnit       string    `gorm:"type:varchar(20)" json:"dose_unit"`
+	WithdrawalDays uint      `json:"withdrawal_days"`
+	Reason         string    `gorm:"type:varchar(255)" json:"reason"`
+	TreatedAt      time.Time `gorm:"index:idx_treatment_pond_treated_at" json:"treated_at"`
+}
+
+// Func to get when the withdrawal period of the treatment is over
+func (treatment TreatmentLog) WithdrawalEndsAt() time.Time {
+	return treatment.TreatedAt.AddDate(0, 0, int(treatment.WithdrawalDays))
+}
+
+// Func to check whether the time is still inside the withdrawal period of the treatment
+func (treatment TreatmentLog) IsWithdrawing(at time.Time) bool {
+	return treatment.WithdrawalDays > 0 && at.Before(treatment.WithdrawalEndsAt())
+}
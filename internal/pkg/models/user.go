@@ -0,0 +1,21 @@
+package models
+
+import "gorm.io/gorm"
+
+// Available roles of user
+const (
+	RoleAdmin       = "admin"
+	RoleFarmManager = "farm_manager"
+	RoleOperator    = "operator"
+	RoleViewer      = "viewer"
+)
+
+// Struct for User Models
+type User struct {
+	gorm.Model
+	Name          string         `gorm:"type:varchar(100)" json:"name"`
+	Email         string         `gorm:"type:varchar(100);uniqueIndex" json:"email"`
+	Password      string         `gorm:"type:varchar(255)" json:"-"`
+	Role          string         `gorm:"type:varchar(20);default:viewer" json:"role"`
+	Organizations []Organization `gorm:"many2many:user_organizations;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"organizations"`
+}
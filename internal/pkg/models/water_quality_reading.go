@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Struct for Water Quality Reading Models.
+// Every parameter is optional, only measured parameter is filled
+type WaterQualityReading struct {
+	gorm.Model
+	PondId          uint      `gorm:"index:idx_reading_pond_measured_at" json:"pond_id"`
+	Pond            Pond      `gorm:"foreignkey:PondId;constraint:onUpdate:CASCADE,onDelete:CASCADE" json:"-"`
+	MeasuredAt      time.Time `gorm:"index:idx_reading_pond_measured_at" json:"measured_at"`
+	DissolvedOxygen *float64  `json:"dissolved_oxygen"`
+	Ph              *float64  `json:"ph"`
+	Temperature     *float64  `json:"temperature"`
+	Salinity        *float64  `json:"salinity"`
+	Ammonia         *float64  `json:"ammonia"`
+	Nitrite         *float64  `json:"nitrite"`
+}
+
+// Water quality parameters
+const (
+	ParameterDissolvedOxygen = "dissolved_oxygen"
+	ParameterPh              = "ph"
+	ParameterTemperature     = "temperature"
+	ParameterSalinity        = "salinity"
+	ParameterAmmonia         = "ammonia"
+	ParameterNitrite         = "nitrite"
+)
+
+// Func to get only the measured parameters of the reading
+func (reading WaterQualityReading) Measurements() map[string]float64 {
+	measurements := map[string]float64{}
+	for parameter, value := range map[string]*float64{
+		ParameterDissolvedOxygen: reading.DissolvedOxygen,
+		ParameterPh:              reading.Ph,
+		ParameterTemperature:     reading.Temperature,
+		ParameterSalinity:        reading.Salinity,
+		ParameterAmmonia:         reading.Ammonia,
+		ParameterNitrite:         reading.Nitrite,
+	} {
+		if value != nil {
+			measurements[parameter] = *value
+		}
+	}
+	return measurements
+}
@@ -0,0 +1,77 @@
+package mortality
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+// Spike is a period where the dead stock in the rolling window reach the threshold.
+// Overlapping windows are merged into one spike
+type Spike struct {
+	Start         time.Time       `json:"start"`
+	End           time.Time       `json:"end"`
+	Deaths        uint            `json:"deaths"`
+	AliveAtStart  uint            `json:"alive_at_start"`
+	MortalityRate float64         `json:"mortality_rate"`
+	Causes        map[string]uint `json:"causes"`
+}
+
+// Func to detect mortality spike of cycle.
+// A window (t - window, t] ending at every log is a spike when its dead stock is at least
+// thresholdPercent of the stock alive at the start of the window
+func DetectSpikes(logs []models.MortalityLog, initialCount uint, window time.Duration, thresholdPercent float64) []Spike {
+	spikes := []Spike{}
+	if window <= 0 {
+		return spikes
+	}
+
+	sortedLogs := make([]models.MortalityLog, len(logs))
+	copy(sortedLogs, logs)
+	sort.SliceStable(sortedLogs, func(i, j int) bool {
+		return sortedLogs[i].RecordedAt.Before(sortedLogs[j].RecordedAt)
+	})
+
+	var deathsBeforeWindow, windowDeaths uint
+	start := 0
+	for _, log := range sortedLogs {
+		windowDeaths += log.Count
+		// Slide the start of the window
+		for !sortedLogs[start].RecordedAt.After(log.RecordedAt.Add(-window)) {
+			windowDeaths -= sortedLogs[start].Count
+			deathsBeforeWindow += sortedLogs[start].Count
+			start++
+		}
+
+		aliveAtStart := helpers.RemainingCount(initialCount, deathsBeforeWindow)
+		if aliveAtStart == 0 || float64(windowDeaths)/float64(aliveAtStart)*100 < thresholdPercent {
+			continue
+		}
+
+		windowStart := sortedLogs[start].RecordedAt
+		lastIndex := len(spikes) - 1
+		if lastIndex >= 0 && !windowStart.After(spikes[lastIndex].End) {
+			spikes[lastIndex].End = log.RecordedAt
+			continue
+		}
+		spikes = append(spikes, Spike{Start: windowStart, End: log.RecordedAt, AliveAtStart: aliveAtStart})
+	}
+
+	// Deaths of the merged spike is counted from the logs inside it
+	for i := range spikes {
+		spikes[i].Causes = map[string]uint{}
+		for _, log := range sortedLogs {
+			if log.RecordedAt.Before(spikes[i].Start) || log.RecordedAt.After(spikes[i].End) {
+				continue
+			}
+			spikes[i].Deaths += log.Count
+			spikes[i].Causes[log.Cause] += log.Count
+		}
+		if spikes[i].AliveAtStart > 0 {
+			spikes[i].MortalityRate = float64(spikes[i].Deaths) / float64(spikes[i].AliveAtStart) * 100
+		}
+	}
+	return spikes
+}
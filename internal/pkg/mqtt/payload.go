@@ -0,0 +1,78 @@
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/telemetry"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+// Error when the topic of the message does not match the subscribed topic
+var ErrInvalidTopic = errors.New("invalid topic")
+
+// Struct of payload published by field gateway, the device of the api key must be registered to the pond of the topic.
+// Each reading has the same format as the JSON body of ingest endpoint
+type Payload struct {
+	DeviceKey string                           `json:"device_key"`
+	Readings  []validator.IngestReadingRequest `json:"readings"`
+}
+
+// Func to get the farm id and pond id from the topic of the message.
+// The first single level wildcard of the subscribed topic is the farm id and the second is the pond id
+func ParseTopic(subscribedTopic string, topic string) (farmId uint, pondId uint, err error) {
+	patternLevels := strings.Split(subscribedTopic, "/")
+	topicLevels := strings.Split(topic, "/")
+	if len(patternLevels) != len(topicLevels) {
+		return 0, 0, fmt.Errorf("%w: %s does not match %s", ErrInvalidTopic, topic, subscribedTopic)
+	}
+
+	var ids []uint
+	for i, level := range patternLevels {
+		if level != "+" {
+			if level != topicLevels[i] {
+				return 0, 0, fmt.Errorf("%w: %s does not match %s", ErrInvalidTopic, topic, subscribedTopic)
+			}
+			continue
+		}
+
+		id, err := helpers.ParseUint(topicLevels[i])
+		if err != nil || id == 0 {
+			return 0, 0, fmt.Errorf("%w: %s is not an id", ErrInvalidTopic, topicLevels[i])
+		}
+		ids = append(ids, id)
+	}
+
+	if len(ids) != 2 {
+		return 0, 0, fmt.Errorf("%w: %s must have farm and pond wildcard", ErrInvalidTopic, subscribedTopic)
+	}
+	return ids[0], ids[1], nil
+}
+
+// Func to map payload of the message into measurements of the device, every reading is validated
+func ParsePayload(payload []byte) (deviceKey string, measurements []models.Measurement, err error) {
+	var message Payload
+	if err := json.Unmarshal(payload, &message); err != nil {
+		return "", nil, fmt.Errorf("%w: %s", telemetry.ErrInvalidReading, err.Error())
+	}
+	if message.DeviceKey == "" {
+		return "", nil, fmt.Errorf("%w: device_key is required", telemetry.ErrInvalidReading)
+	}
+	if len(message.Readings) == 0 {
+		return "", nil, fmt.Errorf("%w: no reading found", telemetry.ErrInvalidReading)
+	}
+
+	measurements = make([]models.Measurement, 0, len(message.Readings))
+	for i, reading := range message.Readings {
+		measurement := reading.ToMeasurement()
+		if err := telemetry.Validate(measurement); err != nil {
+			return "", nil, fmt.Errorf("reading %d: %w", i, err)
+		}
+		measurements = append(measurements, measurement)
+	}
+	return message.DeviceKey, measurements, nil
+}
@@ -0,0 +1,196 @@
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/alert"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	paho "github.com/eclipse/paho.mqtt.golang"
+	"gorm.io/gorm"
+)
+
+// Time to wait for the broker when connecting and subscribing
+const brokerTimeout = 10 * time.Second
+
+// Error when the broker does not respond in time
+var ErrBrokerTimeout = errors.New("mqtt broker does not respond in time")
+
+// Error when the device key of the payload does not belong to any device
+var ErrInvalidDeviceKey = errors.New("device key is not valid")
+
+// Error when the subscriber is not connected to the broker
+var ErrNotConnected = errors.New("mqtt subscriber is not connected")
+
+// Subscriber of readings published by field gateways
+type Subscriber struct {
+	Configuration         config.MqttConfiguration
+	MaxBatchSize          int
+	PondRepository        repository.PondRepositoryInterface
+	DeviceRepository      repository.DeviceRepositoryInterface
+	MeasurementRepository repository.MeasurementRepositoryInterface
+	AlertEngine           alert.EngineInterface
+
+	client     paho.Client
+	subscribed chan error
+}
+
+type SubscriberInterface interface {
+	Start() error
+	Stop()
+	Check(ctx context.Context) error
+	HandleMessage(topic string, payload []byte) (int64, error)
+}
+
+// Func to create new Subscriber with the configuration
+func NewSubscriber(configuration config.MqttConfiguration, maxBatchSize int) *Subscriber {
+	return &Subscriber{
+		Configuration:         configuration,
+		MaxBatchSize:          maxBatchSize,
+		PondRepository:        repository.GetPondRepository(),
+		DeviceRepository:      repository.GetDeviceRepository(),
+		MeasurementRepository: repository.GetMeasurementRepository(),
+		AlertEngine:           alert.GetAlertEngine(),
+	}
+}
+
+// Func to connect to the broker and subscribe the topic.
+// The client retry the first connection and reconnect by itself, and subscribe again after reconnected
+func (subscriber *Subscriber) Start() error {
+	subscriber.subscribed = make(chan error, 1)
+
+	options := paho.NewClientOptions().
+		AddBroker(subscriber.Configuration.BrokerUrl).
+		SetClientID(subscriber.Configuration.ClientId).
+		SetUsername(subscriber.Configuration.Username).
+		SetPassword(subscriber.Configuration.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetOrderMatters(false).
+		SetConnectTimeout(brokerTimeout).
+		SetOnConnectHandler(subscriber.subscribe).
+		SetConnectionLostHandler(func(client paho.Client, err error) {
+			log.Println("mqtt connection lost:", err)
+		})
+	subscriber.client = paho.NewClient(options)
+
+	token := subscriber.client.Connect()
+	if !token.WaitTimeout(brokerTimeout) {
+		return ErrBrokerTimeout
+	}
+	if err := token.Error(); err != nil {
+		return err
+	}
+
+	select {
+	case err := <-subscriber.subscribed:
+		return err
+	case <-time.After(brokerTimeout):
+		return ErrBrokerTimeout
+	}
+}
+
+// Func to disconnect from the broker, waiting for the message that is being handled.
+// The connection that is still retried is cancelled
+func (subscriber *Subscriber) Stop() {
+	if subscriber.client != nil {
+		subscriber.client.Disconnect(uint(brokerTimeout.Milliseconds()))
+	}
+}
+
+// Func to check the connection to the broker, used as health check
+func (subscriber *Subscriber) Check(ctx context.Context) error {
+	if subscriber.client == nil || !subscriber.client.IsConnectionOpen() {
+		return ErrNotConnected
+	}
+	return nil
+}
+
+// Func to store the readings of the message, return the number of stored measurements.
+// The pond of the topic must belong to the farm of the topic and the device of the key must be registered to the pond
+func (subscriber *Subscriber) HandleMessage(topic string, payload []byte) (int64, error) {
+	farmId, pondId, err := ParseTopic(subscriber.Configuration.Topic, topic)
+	if err != nil {
+		return 0, err
+	}
+
+	deviceKey, measurements, err := ParsePayload(payload)
+	if err != nil {
+		return 0, err
+	}
+	if subscriber.MaxBatchSize > 0 && len(measurements) > subscriber.MaxBatchSize {
+		return 0, fmt.Errorf("at most %d readings can be sent in single message", subscriber.MaxBatchSize)
+	}
+
+	wherePond := models.Pond{FarmId: farmId}
+	wherePond.ID = pondId
+	if _, err := subscriber.PondRepository.GetByModel(wherePond); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, fmt.Errorf("%w: pond %d does not exist in farm %d", ErrInvalidTopic, pondId, farmId)
+		}
+		return 0, err
+	}
+
+	// Anyone can publish to the broker, so the device is authenticated by its api key like ingest endpoint
+	apiKeyHash := crypto.GetDeviceKeyCryptoHelper().HashDeviceKey(deviceKey)
+	device, err := subscriber.DeviceRepository.GetByApiKeyHash(apiKeyHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, ErrInvalidDeviceKey
+		}
+		return 0, err
+	}
+	if device.PondId != pondId {
+		return 0, fmt.Errorf("device %d is not registered to pond %d", device.ID, pondId)
+	}
+
+	for i := range measurements {
+		measurements[i].PondId = device.PondId
+		measurements[i].DeviceId = device.ID
+	}
+
+	storedMeasurements, err := subscriber.MeasurementRepository.CreateBatch(measurements)
+	if err != nil {
+		return 0, err
+	}
+
+	// The readings are already stored, so failure on evaluation or marking the device is only logged.
+	// Only the stored readings are evaluated, so resent reading does not count again on its alert
+	if len(storedMeasurements) > 0 {
+		if _, err := subscriber.AlertEngine.EvaluateMeasurements(storedMeasurements); err != nil {
+			log.Println("failed to evaluate alert of measurements:", err)
+		}
+	}
+	if err := subscriber.DeviceRepository.UpdateLastSeen(device, time.Now()); err != nil {
+		log.Println("failed to update last seen of device:", err)
+	}
+	return int64(len(storedMeasurements)), nil
+}
+
+// Helper to subscribe the topic on every (re)connect, subscription is lost when the session is clean
+func (subscriber *Subscriber) subscribe(client paho.Client) {
+	token := client.Subscribe(subscriber.Configuration.Topic, subscriber.Configuration.Qos, subscriber.onMessage)
+	token.Wait()
+	if err := token.Error(); err != nil {
+		log.Println("failed to subscribe mqtt topic:", err)
+	}
+
+	// Only the first subscription is waited by Start
+	select {
+	case subscriber.subscribed <- token.Error():
+	default:
+	}
+}
+
+// Helper to handle message from the broker, there is no one to answer so failure is only logged
+func (subscriber *Subscriber) onMessage(client paho.Client, message paho.Message) {
+	if _, err := subscriber.HandleMessage(message.Topic(), message.Payload()); err != nil {
+		log.Printf("failed to store readings of mqtt topic %s: %v\n", message.Topic(), err)
+	}
+}
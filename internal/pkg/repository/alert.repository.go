@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+)
+
+var alertRepository *AlertRepository
+
+// Fields of alert that can be used for sorting
+var AlertSortableFields = []string{"id", "severity", "status", "created_at", "updated_at"}
+
+// Filter for listing alerts
+type AlertFilter struct {
+	PondId   uint
+	Status   string
+	Severity string
+}
+
+// Alert Repository. If "scoped" is true, every query is limited to
+// alerts whose pond belong to one of the OrganizationIds (tenant)
+type AlertRepository struct {
+	OrganizationIds []uint
+	scoped          bool
+}
+
+type AlertRepositoryInterface interface {
+	WithTenant(organizationIds []uint) AlertRepositoryInterface
+	Create(alert models.Alert) (models.Alert, error)
+	GetAllPaginated(pagination helpers.Pagination, filter AlertFilter) (*helpers.Pagination, error)
+	GetById(alertId string) (*models.Alert, error)
+	GetUnresolved(pondId uint, parameter string) (*models.Alert, error)
+	GetAllByPondInRange(pondId uint, from, to time.Time) (*[]models.Alert, error)
+	Update(alert *models.Alert) error
+	Merge(alert *models.Alert) error
+}
+
+// Func to return Alert Repository instance
+func GetAlertRepository() AlertRepositoryInterface {
+	if alertRepository == nil {
+		alertRepository = &AlertRepository{}
+	}
+	return alertRepository
+}
+
+// Func to return new Alert Repository scoped to the tenant
+func (repo *AlertRepository) WithTenant(organizationIds []uint) AlertRepositoryInterface {
+	return &AlertRepository{
+		OrganizationIds: organizationIds,
+		scoped:          true,
+	}
+}
+
+// Func to return the tenant scope of the repository
+func (repo *AlertRepository) scopes() []Scope {
+	if !repo.scoped {
+		return []Scope{}
+	}
+	return []Scope{PondChildTenantScope("alerts", repo.OrganizationIds)}
+}
+
+// Scope to limit query of table that belong to pond (has "pond_id") to the tenant
+func PondChildTenantScope(table string, organizationIds []uint) Scope {
+	return func(tx *gorm.DB) *gorm.DB {
+		tenantPonds := db.GetDB().Model(&models.Pond{}).Select("id").Scopes(PondTenantScope(organizationIds))
+		return tx.Where(table+".pond_id IN (?)", tenantPonds)
+	}
+}
+
+// Func to Create Alert
+func (repo *AlertRepository) Create(alert models.Alert) (models.Alert, error) {
+	err := Create(&alert)
+	if err != nil {
+		return models.Alert{}, err
+	}
+	return alert, nil
+}
+
+// Func to get All Alert with Pagination and filter
+func (repo *AlertRepository) GetAllPaginated(pagination helpers.Pagination, filter AlertFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(AlertSortableFields); err != nil {
+		return nil, err
+	}
+
+	var alerts []models.Alert
+	where := &models.Alert{PondId: filter.PondId, Status: filter.Status, Severity: filter.Severity}
+	return Query(where, &alerts, pagination, []string{}, repo.scopes()...)
+}
+
+// Func to Get Alert by Id
+func (repo *AlertRepository) GetById(alertId string) (*models.Alert, error) {
+	var alert models.Alert
+	where := models.Alert{}
+	where.ID, _ = helpers.ParseUint(alertId)
+	_, err := First(&where, &alert, []string{}, repo.scopes()...)
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// Func to Get the alert of pond parameter that is not resolved yet
+func (repo *AlertRepository) GetUnresolved(pondId uint, parameter string) (*models.Alert, error) {
+	var alert models.Alert
+	where := models.Alert{PondId: pondId, Parameter: parameter}
+	unresolved := func(db *gorm.DB) *gorm.DB {
+		return db.Where("status <> ?", models.AlertStatusResolved)
+	}
+	_, err := First(&where, &alert, []string{}, append(repo.scopes(), unresolved)...)
+	if err != nil {
+		return nil, err
+	}
+	return &alert, nil
+}
+
+// Func to Get All Alert of pond that is raised or occurred again in time range
+func (repo *AlertRepository) GetAllByPondInRange(pondId uint, from, to time.Time) (*[]models.Alert, error) {
+	var alerts []models.Alert
+	inRange := func(db *gorm.DB) *gorm.DB {
+		return db.Where("created_at <= ? AND updated_at >= ?", to, from)
+	}
+	err := Find(&models.Alert{PondId: pondId}, &alerts, []string{}, append(repo.scopes(), inRange, OrderBy("created_at asc"))...)
+	return &alerts, err
+}
+
+// Func to Update Alert by Model defined
+func (repo *AlertRepository) Update(alert *models.Alert) error {
+	return Save(alert, repo.scopes()...)
+}
+
+// Func to save the alert that new reading is merged into. The columns are selected,
+// so zero reading id and nil measurement id (or bound) of the latest source are saved too
+func (repo *AlertRepository) Merge(alert *models.Alert) error {
+	mergedColumns := []string{"reading_id", "measurement_id", "value", "min", "max", "severity", "occurrences"}
+	return db.GetDB().Model(alert).Scopes(toGormScopes(repo.scopes())...).Select(mergedColumns).Updates(alert).Error
+}
@@ -3,26 +3,45 @@ package repository
 import (
 	"errors"
 	"math"
+	"reflect"
+	"strings"
 
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
 	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
 	"gorm.io/gorm"
 )
 
+// Scope that can be applied to the query, e.g. tenant scoping or ordering
+type Scope func(db *gorm.DB) *gorm.DB
+
+// Scope to order the query
+func OrderBy(order string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Order(order)
+	}
+}
+
+// Scope to filter column that contains the value (case insensitive)
+func NameContainsScope(column string, value string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("LOWER("+column+") LIKE ?", "%"+strings.ToLower(value)+"%")
+	}
+}
+
 // Common function to create in db
 func Create(value interface{}) error {
 	return db.GetDB().Create(value).Error
 }
 
 // Common function to save in db
-func Save(value interface{}) error {
-	return db.GetDB().Updates(value).Error
+func Save(value interface{}, scopes ...Scope) error {
+	return db.GetDB().Scopes(toGormScopes(scopes)...).Updates(value).Error
 }
 
 // Common function to get the first row
 // Associations mean its relation to other
-func First(where interface{}, out interface{}, associations []string) (notFound bool, err error) {
-	db := db.GetDB()
+func First(where interface{}, out interface{}, associations []string, scopes ...Scope) (notFound bool, err error) {
+	db := db.GetDB().Scopes(toGormScopes(scopes)...)
 	for _, a := range associations {
 		db = db.Preload(a)
 	}
@@ -39,50 +58,122 @@ func Update(where, value interface{}) error {
 }
 
 // Common function to find in db
-func Find(where interface{}, output interface{}, associations []string, orders ...string) error {
-	db := db.GetDB()
+func Find(where interface{}, output interface{}, associations []string, scopes ...Scope) error {
+	db := db.GetDB().Scopes(toGormScopes(scopes)...)
 	for _, a := range associations {
 		db = db.Preload(a)
 	}
 	db = db.Where(where)
-	if len(orders) > 0 {
-		for _, order := range orders {
-			db = db.Order(order)
-		}
-	}
 	return db.Find(output).Error
 }
 
 // Common function to paginate by model in db
-func Query(where interface{}, output interface{}, pagination helpers.Pagination, associations []string) (*helpers.Pagination, error) {
-	db := db.GetDB()
-	db.Scopes(paginate(where, &pagination, db))
+func Query(where interface{}, output interface{}, pagination helpers.Pagination, associations []string, scopes ...Scope) (*helpers.Pagination, error) {
+	// Session so the base query can be reused for counting and finding
+	db := db.GetDB().Model(where).Scopes(toGormScopes(scopes)...).Where(where).Session(&gorm.Session{})
+
+	if pagination.CursorMode {
+		return queryByCursor(db, output, pagination, associations)
+	}
+
+	var totalRows int64
+	if err := db.Count(&totalRows).Error; err != nil {
+		return nil, err
+	}
+	pagination.TotalRows = totalRows
+	pagination.TotalPages = int(math.Ceil(float64(totalRows) / float64(pagination.GetLimit())))
+
 	// preload the associations
+	db = db.Scopes(paginate(&pagination))
 	for _, a := range associations {
 		db = db.Preload(a)
 	}
-	db.Find(output)
+	if err := db.Find(output).Error; err != nil {
+		return nil, err
+	}
 	pagination.Rows = output
 	return &pagination, nil
 }
 
-// Func pagination for scope
-func paginate(value interface{}, pagination *helpers.Pagination, db *gorm.DB) func(db *gorm.DB) *gorm.DB {
-	var totalRows int64
-	db.Model(value).Count(&totalRows)
+// Func keyset pagination by id. The rows are always returned in the order of Sort
+// ("id asc" or "id desc"), cursor only tell from which id and which direction to go
+func queryByCursor(db *gorm.DB, output interface{}, pagination helpers.Pagination, associations []string) (*helpers.Pagination, error) {
+	sortField := strings.Fields(pagination.GetSort())
+	if len(sortField) != 2 || sortField[0] != "id" {
+		return nil, helpers.ErrInvalidSort
+	}
+	ascending := sortField[1] == "asc"
 
-	pagination.TotalRows = totalRows
-	totalPages := int(math.Ceil(float64(totalRows) / float64(pagination.GetLimit())))
-	pagination.TotalPages = totalPages
+	direction := helpers.CursorNext
+	if pagination.Cursor != "" {
+		var cursorId uint
+		var err error
+		cursorId, direction, err = helpers.DecodeCursor(pagination.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		// Going forward on ascending (or backward on descending) need greater id
+		if ascending == (direction == helpers.CursorNext) {
+			db = db.Where("id > ?", cursorId)
+		} else {
+			db = db.Where("id < ?", cursorId)
+		}
+	}
+
+	// Backward query is ordered in reverse, then reversed again after fetched
+	order := "id asc"
+	if ascending != (direction == helpers.CursorNext) {
+		order = "id desc"
+	}
+
+	// Fetch one more row to know whether there is still another page
+	db = db.Order(order).Limit(pagination.GetLimit() + 1)
+	for _, a := range associations {
+		db = db.Preload(a)
+	}
+	if err := db.Find(output).Error; err != nil {
+		return nil, err
+	}
+
+	rows := reflect.ValueOf(output).Elem()
+	hasMore := rows.Len() > pagination.GetLimit()
+	if hasMore {
+		rows.Set(rows.Slice(0, pagination.GetLimit()))
+	}
+	if direction == helpers.CursorPrev {
+		for i, j := 0, rows.Len()-1; i < j; i, j = i+1, j-1 {
+			first, last := rows.Index(i).Interface(), rows.Index(j).Interface()
+			rows.Index(i).Set(reflect.ValueOf(last))
+			rows.Index(j).Set(reflect.ValueOf(first))
+		}
+	}
 
+	if rows.Len() > 0 {
+		firstId := uint(rows.Index(0).FieldByName("ID").Uint())
+		lastId := uint(rows.Index(rows.Len() - 1).FieldByName("ID").Uint())
+		if (direction == helpers.CursorNext && hasMore) || direction == helpers.CursorPrev {
+			pagination.NextCursor = helpers.EncodeCursor(lastId, helpers.CursorNext)
+		}
+		if (direction == helpers.CursorPrev && hasMore) || (direction == helpers.CursorNext && pagination.Cursor != "") {
+			pagination.PrevCursor = helpers.EncodeCursor(firstId, helpers.CursorPrev)
+		}
+	}
+
+	pagination.Rows = output
+	return &pagination, nil
+}
+
+// Func pagination for scope
+func paginate(pagination *helpers.Pagination) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
 		return db.Offset(pagination.GetOffset()).Limit(pagination.GetLimit()).Order(pagination.GetSort())
 	}
 }
 
 // Common function to delete by model in db
-func DeleteByModel(model interface{}) (count int64, err error) {
-	db := db.GetDB().Delete(model)
+func DeleteByModel(model interface{}, scopes ...Scope) (count int64, err error) {
+	db := db.GetDB().Scopes(toGormScopes(scopes)...).Delete(model)
 	err = db.Error
 	if err != nil {
 		return
@@ -123,3 +214,12 @@ func DeleteByIDS(model interface{}, ids []uint64) (count int64, err error) {
 	count = db.RowsAffected
 	return
 }
+
+// Helper to convert Scope into gorm scope func
+func toGormScopes(scopes []Scope) []func(*gorm.DB) *gorm.DB {
+	gormScopes := make([]func(*gorm.DB) *gorm.DB, 0, len(scopes))
+	for _, scope := range scopes {
+		gormScopes = append(gormScopes, scope)
+	}
+	return gormScopes
+}
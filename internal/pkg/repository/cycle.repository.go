@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var cycleRepository *CycleRepository
+
+// Error when the pond already has another active cycle
+var ErrActiveCycleExists = errors.New("pond already has active cycle")
+
+type CycleRepository struct {
+}
+
+type CycleRepositoryInterface interface {
+	Create(cycle models.Cycle) (models.Cycle, error)
+	GetAllByPond(pondId uint) (*[]models.Cycle, error)
+	GetByModel(where models.Cycle) (*models.Cycle, error)
+	GetActiveByPond(pondId uint) (*models.Cycle, error)
+	Update(cycle *models.Cycle) error
+	SaveActive(cycle *models.Cycle) error
+}
+
+// Func to return Cycle Repository instance
+func GetCycleRepository() CycleRepositoryInterface {
+	if cycleRepository == nil {
+		cycleRepository = &CycleRepository{}
+	}
+	return cycleRepository
+}
+
+// Func to Create Cycle
+func (repo *CycleRepository) Create(cycle models.Cycle) (models.Cycle, error) {
+	err := Create(&cycle)
+	if err != nil {
+		return models.Cycle{}, err
+	}
+	return cycle, nil
+}
+
+// Func to get All Cycle of Pond, the latest stocking first
+func (repo *CycleRepository) GetAllByPond(pondId uint) (*[]models.Cycle, error) {
+	var cycles []models.Cycle
+	err := Find(&models.Cycle{PondId: pondId}, &cycles, []string{}, OrderBy("stocking_date desc"), OrderBy("id desc"))
+	return &cycles, err
+}
+
+// Func to Get from Struct Model defined
+func (repo *CycleRepository) GetByModel(where models.Cycle) (*models.Cycle, error) {
+	var cycle models.Cycle
+	_, err := First(&where, &cycle, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &cycle, err
+}
+
+// Func to Get the active Cycle of Pond
+func (repo *CycleRepository) GetActiveByPond(pondId uint) (*models.Cycle, error) {
+	return repo.GetByModel(models.Cycle{PondId: pondId, Status: models.CycleStatusActive})
+}
+
+// Func to Update Cycle by Model defined in handler
+func (repo *CycleRepository) Update(cycle *models.Cycle) error {
+	return Save(cycle)
+}
+
+// Func to Save Cycle as the active cycle of its pond and mark the pond as stocked.
+// The pond is locked while the other active cycle is checked, so concurrent activation can not stock it twice
+func (repo *CycleRepository) SaveActive(cycle *models.Cycle) error {
+	return db.GetDB().Transaction(func(tx *gorm.DB) error {
+		var pond models.Pond
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&pond, cycle.PondId).Error; err != nil {
+			return err
+		}
+
+		var activeCount int64
+		err := tx.Model(&models.Cycle{}).
+			Where("pond_id = ? AND status = ? AND id <> ?", cycle.PondId, models.CycleStatusActive, cycle.ID).
+			Count(&activeCount).Error
+		if err != nil {
+			return err
+		}
+		if activeCount > 0 {
+			return ErrActiveCycleExists
+		}
+
+		cycle.Status = models.CycleStatusActive
+		if err := tx.Save(cycle).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Pond{}).Where("id = ?", cycle.PondId).Update("occupancy", models.PondOccupancyStocked).Error
+	})
+}
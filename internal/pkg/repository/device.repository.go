@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+var deviceRepository *DeviceRepository
+
+// Fields of device that can be used for sorting
+var DeviceSortableFields = []string{"id", "name", "last_seen_at", "created_at"}
+
+type DeviceRepository struct {
+}
+
+type DeviceRepositoryInterface interface {
+	Create(device models.Device) (models.Device, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination) (*helpers.Pagination, error)
+	GetByModel(where models.Device) (*models.Device, error)
+	GetByApiKeyHash(apiKeyHash string) (*models.Device, error)
+	UpdateLastSeen(device *models.Device, seenAt time.Time) error
+	Delete(device *models.Device) error
+}
+
+// Func to return Device Repository instance
+func GetDeviceRepository() DeviceRepositoryInterface {
+	if deviceRepository == nil {
+		deviceRepository = &DeviceRepository{}
+	}
+	return deviceRepository
+}
+
+// Func to Create Device
+func (repo *DeviceRepository) Create(device models.Device) (models.Device, error) {
+	err := Create(&device)
+	if err != nil {
+		return models.Device{}, err
+	}
+	return device, nil
+}
+
+// Func to get All Device of Pond with Pagination
+func (repo *DeviceRepository) GetAllByPond(pondId uint, pagination helpers.Pagination) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(DeviceSortableFields); err != nil {
+		return nil, err
+	}
+
+	var devices []models.Device
+	where := &models.Device{PondId: pondId}
+	return Query(where, &devices, pagination, []string{})
+}
+
+// Func to Get from Struct Model defined
+func (repo *DeviceRepository) GetByModel(where models.Device) (*models.Device, error) {
+	var device models.Device
+	_, err := First(&where, &device, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &device, err
+}
+
+// Func to Get Device by the hash of its api key
+func (repo *DeviceRepository) GetByApiKeyHash(apiKeyHash string) (*models.Device, error) {
+	var device models.Device
+	_, err := First(&models.Device{ApiKeyHash: apiKeyHash}, &device, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &device, nil
+}
+
+// Func to mark when the device send its latest readings
+func (repo *DeviceRepository) UpdateLastSeen(device *models.Device, seenAt time.Time) error {
+	device.LastSeenAt = &seenAt
+	return db.GetDB().Model(device).UpdateColumn("last_seen_at", seenAt).Error
+}
+
+// Func to Delete Device by Model defined in handler
+func (repo *DeviceRepository) Delete(device *models.Device) error {
+	_, err := DeleteByModel(device)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,22 +1,40 @@
 package repository
 
 import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
 )
 
 var farmRepository *FarmRepository
 
 type FarmRepositoryInterface interface {
+	WithTenant(organizationIds []uint) FarmRepositoryInterface
 	Create(farm models.Farm) (models.Farm, error)
 	GetAll() (*[]models.Farm, error)
+	GetAllPaginated(pagination helpers.Pagination, filter FarmFilter) (*helpers.Pagination, error)
+	Count() (int64, error)
 	GetById(farmId string) (*models.Farm, error)
 	GetByModel(where models.Farm) (*models.Farm, error)
 	Update(farm *models.Farm) error
 	Delete(farm *models.Farm) error
 }
 
+// Fields of farm that can be used for sorting
+var FarmSortableFields = []string{"id", "name", "created_at", "updated_at"}
+
+// Filter for listing farms
+type FarmFilter struct {
+	Name        string
+	BoundingBox *helpers.BoundingBox
+}
+
+// Farm Repository. If "scoped" is true, every query is limited to
+// farms that belong to one of the OrganizationIds (tenant)
 type FarmRepository struct {
+	OrganizationIds []uint
+	scoped          bool
 }
 
 // Func to return instance of Farm Repository
@@ -27,6 +45,29 @@ func GetFarmRepository() FarmRepositoryInterface {
 	return farmRepository
 }
 
+// Func to return new Farm Repository scoped to the tenant
+func (repo *FarmRepository) WithTenant(organizationIds []uint) FarmRepositoryInterface {
+	return &FarmRepository{
+		OrganizationIds: organizationIds,
+		scoped:          true,
+	}
+}
+
+// Func to return the tenant scope of the repository
+func (repo *FarmRepository) scopes() []Scope {
+	if !repo.scoped {
+		return []Scope{}
+	}
+	return []Scope{FarmTenantScope(repo.OrganizationIds)}
+}
+
+// Scope to limit farms query to the tenant
+func FarmTenantScope(organizationIds []uint) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("farms.organization_id IN ?", organizationIds)
+	}
+}
+
 // Func to Create Farm
 func (repo *FarmRepository) Create(farm models.Farm) (models.Farm, error) {
 	err := Create(&farm)
@@ -39,16 +80,50 @@ func (repo *FarmRepository) Create(farm models.Farm) (models.Farm, error) {
 // Func to get All Farm without Pagination
 func (repo *FarmRepository) GetAll() (*[]models.Farm, error) {
 	var farms []models.Farm
-	err := Find(&models.Farm{}, &farms, []string{"Ponds"}, "id asc")
+	err := Find(&models.Farm{}, &farms, []string{"Ponds"}, append(repo.scopes(), OrderBy("id asc"))...)
 	return &farms, err
 }
 
+// Func to Count Farm
+func (repo *FarmRepository) Count() (int64, error) {
+	var count int64
+	err := db.GetDB().Model(&models.Farm{}).Scopes(toGormScopes(repo.scopes())...).Count(&count).Error
+	return count, err
+}
+
+// Func to get All Farm with Pagination, sorting and filtering
+func (repo *FarmRepository) GetAllPaginated(pagination helpers.Pagination, filter FarmFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(FarmSortableFields); err != nil {
+		return nil, err
+	}
+
+	scopes := repo.scopes()
+	if filter.Name != "" {
+		scopes = append(scopes, NameContainsScope("farms.name", filter.Name))
+	}
+	if filter.BoundingBox != nil {
+		scopes = append(scopes, BoundingBoxScope(*filter.BoundingBox))
+	}
+
+	var farms []models.Farm
+	return Query(&models.Farm{}, &farms, pagination, []string{"Ponds"}, scopes...)
+}
+
+// Scope to limit farms to the ones located inside the bounding box.
+// Farm without location is excluded
+func BoundingBoxScope(box helpers.BoundingBox) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("farms.longitude BETWEEN ? AND ?", box.MinLongitude, box.MaxLongitude).
+			Where("farms.latitude BETWEEN ? AND ?", box.MinLatitude, box.MaxLatitude)
+	}
+}
+
 // Func to get By Id
 func (repo *FarmRepository) GetById(farmId string) (*models.Farm, error) {
 	var farm models.Farm
 	where := models.Farm{}
 	where.ID, _ = helpers.ParseUint(farmId)
-	_, err := First(&where, &farm, []string{"Ponds"})
+	_, err := First(&where, &farm, []string{"Ponds"}, repo.scopes()...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +133,7 @@ func (repo *FarmRepository) GetById(farmId string) (*models.Farm, error) {
 // Func to Get from Struct Model defined
 func (repo *FarmRepository) GetByModel(where models.Farm) (*models.Farm, error) {
 	var farm models.Farm
-	_, err := First(&where, &farm, []string{})
+	_, err := First(&where, &farm, []string{}, repo.scopes()...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,12 +142,12 @@ func (repo *FarmRepository) GetByModel(where models.Farm) (*models.Farm, error)
 
 // Func to update farm according to model defined
 func (repo *FarmRepository) Update(farm *models.Farm) error {
-	return Save(farm)
+	return Save(farm, repo.scopes()...)
 }
 
 // Func to delete farm according to model defined
 func (repo *FarmRepository) Delete(farm *models.Farm) error {
-	_, err := DeleteByModel(farm)
+	_, err := DeleteByModel(farm, repo.scopes()...)
 	if err != nil {
 		return err
 	}
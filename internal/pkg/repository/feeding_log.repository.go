@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+)
+
+var feedingLogRepository *FeedingLogRepository
+
+// Fields of feeding log that can be used for sorting
+var FeedingLogSortableFields = []string{"id", "fed_at", "quantity", "created_at"}
+
+// Filter for listing feeding logs, zero value means not filtered
+type FeedingLogFilter struct {
+	FeedType string
+	From     time.Time
+	To       time.Time
+}
+
+type FeedingLogRepository struct {
+}
+
+type FeedingLogRepositoryInterface interface {
+	Create(feedingLog models.FeedingLog) (models.FeedingLog, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter FeedingLogFilter) (*helpers.Pagination, error)
+	GetByModel(where models.FeedingLog) (*models.FeedingLog, error)
+	Delete(feedingLog *models.FeedingLog) error
+	SumByPond(pondId uint, from, to time.Time) ([]models.FeedingTotal, error)
+}
+
+// Func to return Feeding Log Repository instance
+func GetFeedingLogRepository() FeedingLogRepositoryInterface {
+	if feedingLogRepository == nil {
+		feedingLogRepository = &FeedingLogRepository{}
+	}
+	return feedingLogRepository
+}
+
+// Scope to filter feeding log by feed type
+func feedTypeScope(feedType string) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if feedType == "" {
+			return db
+		}
+		return db.Where("feed_type = ?", feedType)
+	}
+}
+
+// Func to Create Feeding Log
+func (repo *FeedingLogRepository) Create(feedingLog models.FeedingLog) (models.FeedingLog, error) {
+	err := Create(&feedingLog)
+	if err != nil {
+		return models.FeedingLog{}, err
+	}
+	return feedingLog, nil
+}
+
+// Func to get All Feeding Log of Pond with Pagination, time range and feed type
+func (repo *FeedingLogRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter FeedingLogFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(FeedingLogSortableFields); err != nil {
+		return nil, err
+	}
+
+	var feedingLogs []models.FeedingLog
+	where := &models.FeedingLog{PondId: pondId}
+	return Query(where, &feedingLogs, pagination, []string{}, TimeRangeScope("fed_at", filter.From, filter.To), feedTypeScope(filter.FeedType))
+}
+
+// Func to Get from Struct Model defined
+func (repo *FeedingLogRepository) GetByModel(where models.FeedingLog) (*models.FeedingLog, error) {
+	var feedingLog models.FeedingLog
+	_, err := First(&where, &feedingLog, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &feedingLog, err
+}
+
+// Func to Delete Feeding Log by Model defined in handler
+func (repo *FeedingLogRepository) Delete(feedingLog *models.FeedingLog) error {
+	_, err := DeleteByModel(feedingLog)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Func to sum the feed given to pond in time range, grouped by feed type
+func (repo *FeedingLogRepository) SumByPond(pondId uint, from, to time.Time) ([]models.FeedingTotal, error) {
+	var totals []models.FeedingTotal
+	err := db.GetDB().Model(&models.FeedingLog{}).
+		Scopes(toGormScopes([]Scope{TimeRangeScope("fed_at", from, to)})...).
+		Select("feed_type, SUM(quantity) AS quantity, COUNT(*) AS feedings").
+		Where("pond_id = ?", pondId).
+		Group("feed_type").
+		Order("feed_type asc").
+		Scan(&totals).Error
+	return totals, err
+}
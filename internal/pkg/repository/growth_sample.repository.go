@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+var growthSampleRepository *GrowthSampleRepository
+
+// Fields of growth sample that can be used for sorting
+var GrowthSampleSortableFields = []string{"id", "sampled_at", "created_at"}
+
+// Filter for listing growth samples, zero value means not filtered
+type GrowthSampleFilter struct {
+	CycleId uint
+}
+
+type GrowthSampleRepository struct {
+}
+
+type GrowthSampleRepositoryInterface interface {
+	Create(sample models.GrowthSample) (models.GrowthSample, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter GrowthSampleFilter) (*helpers.Pagination, error)
+	GetByModel(where models.GrowthSample) (*models.GrowthSample, error)
+	GetLatestByCycle(cycleId uint) (*models.GrowthSample, error)
+	Delete(sample *models.GrowthSample) error
+}
+
+// Func to return Growth Sample Repository instance
+func GetGrowthSampleRepository() GrowthSampleRepositoryInterface {
+	if growthSampleRepository == nil {
+		growthSampleRepository = &GrowthSampleRepository{}
+	}
+	return growthSampleRepository
+}
+
+// Func to Create Growth Sample
+func (repo *GrowthSampleRepository) Create(sample models.GrowthSample) (models.GrowthSample, error) {
+	err := Create(&sample)
+	if err != nil {
+		return models.GrowthSample{}, err
+	}
+	return sample, nil
+}
+
+// Func to get All Growth Sample of Pond with Pagination, optionally of a cycle only
+func (repo *GrowthSampleRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter GrowthSampleFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(GrowthSampleSortableFields); err != nil {
+		return nil, err
+	}
+
+	var samples []models.GrowthSample
+	where := &models.GrowthSample{PondId: pondId, CycleId: filter.CycleId}
+	return Query(where, &samples, pagination, []string{})
+}
+
+// Func to Get from Struct Model defined
+func (repo *GrowthSampleRepository) GetByModel(where models.GrowthSample) (*models.GrowthSample, error) {
+	var sample models.GrowthSample
+	_, err := First(&where, &sample, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &sample, err
+}
+
+// Func to Get the latest Growth Sample of Cycle
+func (repo *GrowthSampleRepository) GetLatestByCycle(cycleId uint) (*models.GrowthSample, error) {
+	var sample models.GrowthSample
+	// Order must be set before First, since First orders by primary key
+	err := db.GetDB().Where(&models.GrowthSample{CycleId: cycleId}).Order("sampled_at desc").Order("id desc").First(&sample).Error
+	if err != nil {
+		return nil, err
+	}
+	return &sample, nil
+}
+
+// Func to Delete Growth Sample by Model defined in handler
+func (repo *GrowthSampleRepository) Delete(sample *models.GrowthSample) error {
+	_, err := DeleteByModel(sample)
+	if err != nil {
+		return err
+	}
+	return nil
+}
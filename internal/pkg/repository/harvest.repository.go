@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+)
+
+var harvestRepository *HarvestRepository
+
+// Fields of harvest that can be used for sorting
+var HarvestSortableFields = []string{"id", "harvested_at", "total_weight", "created_at"}
+
+// Filter for listing harvests, zero value means not filtered
+type HarvestFilter struct {
+	CycleId uint
+	From    time.Time
+	To      time.Time
+}
+
+type HarvestRepository struct {
+}
+
+type HarvestRepositoryInterface interface {
+	Create(harvest models.Harvest) (models.Harvest, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter HarvestFilter) (*helpers.Pagination, error)
+	GetAllByPonds(pondIds []uint, from, to time.Time) (*[]models.Harvest, error)
+	SumQuantityByCycle(cycleId uint) (uint, error)
+}
+
+// Func to return Harvest Repository instance
+func GetHarvestRepository() HarvestRepositoryInterface {
+	if harvestRepository == nil {
+		harvestRepository = &HarvestRepository{}
+	}
+	return harvestRepository
+}
+
+// Func to Create Harvest. Full harvest also end the cycle as harvested
+// and mark the pond as empty in the same transaction
+func (repo *HarvestRepository) Create(harvest models.Harvest) (models.Harvest, error) {
+	err := db.GetDB().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&harvest).Error; err != nil {
+			return err
+		}
+		if harvest.Type != models.HarvestTypeFull {
+			return nil
+		}
+
+		endCycle := map[string]interface{}{"status": models.CycleStatusHarvested, "ended_at": harvest.HarvestedAt}
+		if err := tx.Model(&models.Cycle{}).Where("id = ?", harvest.CycleId).Updates(endCycle).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Pond{}).Where("id = ?", harvest.PondId).Update("occupancy", models.PondOccupancyEmpty).Error
+	})
+	if err != nil {
+		return models.Harvest{}, err
+	}
+	return harvest, nil
+}
+
+// Func to get All Harvest of Pond with Pagination, time range and cycle
+func (repo *HarvestRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter HarvestFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(HarvestSortableFields); err != nil {
+		return nil, err
+	}
+
+	var harvests []models.Harvest
+	where := &models.Harvest{PondId: pondId, CycleId: filter.CycleId}
+	return Query(where, &harvests, pagination, []string{}, TimeRangeScope("harvested_at", filter.From, filter.To))
+}
+
+// Func to get All Harvest of Ponds in time range with its Cycle, the oldest first
+func (repo *HarvestRepository) GetAllByPonds(pondIds []uint, from, to time.Time) (*[]models.Harvest, error) {
+	var harvests []models.Harvest
+	if len(pondIds) == 0 {
+		return &harvests, nil
+	}
+	inPonds := func(db *gorm.DB) *gorm.DB {
+		return db.Where("pond_id IN ?", pondIds)
+	}
+	err := Find(&models.Harvest{}, &harvests, []string{"Cycle"}, inPonds, TimeRangeScope("harvested_at", from, to), OrderBy("harvested_at asc"), OrderBy("id asc"))
+	return &harvests, err
+}
+
+// Func to sum the harvested quantity of cycle
+func (repo *HarvestRepository) SumQuantityByCycle(cycleId uint) (uint, error) {
+	var quantity uint
+	err := db.GetDB().Model(&models.Harvest{}).
+		Select("COALESCE(SUM(quantity), 0)").
+		Where("cycle_id = ?", cycleId).
+		Scan(&quantity).Error
+	return quantity, err
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+)
+
+var inventoryItemRepository *InventoryItemRepository
+
+// Fields of inventory item that can be used for sorting
+var InventoryItemSortableFields = []string{"id", "name", "category", "created_at", "updated_at"}
+
+// Sub query of the on-hand balance of inventory item, sum of its stock movements
+const inventoryBalanceSubQuery = "(SELECT COALESCE(SUM(stock_movements.quantity), 0) FROM stock_movements" +
+	" WHERE stock_movements.item_id = inventory_items.id AND stock_movements.deleted_at IS NULL)"
+
+// Filter for listing inventory items, zero value means not filtered
+type InventoryItemFilter struct {
+	Name     string
+	Category string
+	LowStock bool
+}
+
+// Struct of on-hand balance of inventory item
+type InventoryBalance struct {
+	ItemId uint
+	OnHand float64
+}
+
+type InventoryItemRepository struct {
+}
+
+type InventoryItemRepositoryInterface interface {
+	Create(item models.InventoryItem) (models.InventoryItem, error)
+	GetAllByFarm(farmId uint, pagination helpers.Pagination, filter InventoryItemFilter) (*helpers.Pagination, error)
+	GetByModel(where models.InventoryItem) (*models.InventoryItem, error)
+	GetBalances(itemIds []uint) (map[uint]float64, error)
+	Update(item *models.InventoryItem) error
+	Delete(item *models.InventoryItem) error
+}
+
+// Func to return Inventory Item Repository instance
+func GetInventoryItemRepository() InventoryItemRepositoryInterface {
+	if inventoryItemRepository == nil {
+		inventoryItemRepository = &InventoryItemRepository{}
+	}
+	return inventoryItemRepository
+}
+
+// Scope to filter inventory item by the filter
+func inventoryItemFilterScope(filter InventoryItemFilter) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.Name != "" {
+			db = NameContainsScope("inventory_items.name", filter.Name)(db)
+		}
+		if filter.Category != "" {
+			db = db.Where("inventory_items.category = ?", filter.Category)
+		}
+		if filter.LowStock {
+			db = db.Where("inventory_items.low_stock_threshold IS NOT NULL AND " + inventoryBalanceSubQuery + " <= inventory_items.low_stock_threshold")
+		}
+		return db
+	}
+}
+
+// Func to Create Inventory Item
+func (repo *InventoryItemRepository) Create(item models.InventoryItem) (models.InventoryItem, error) {
+	err := Create(&item)
+	if err != nil {
+		return models.InventoryItem{}, err
+	}
+	return item, nil
+}
+
+// Func to get All Inventory Item of Farm with Pagination and filter
+func (repo *InventoryItemRepository) GetAllByFarm(farmId uint, pagination helpers.Pagination, filter InventoryItemFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(InventoryItemSortableFields); err != nil {
+		return nil, err
+	}
+
+	var items []models.InventoryItem
+	where := &models.InventoryItem{FarmId: farmId}
+	return Query(where, &items, pagination, []string{}, inventoryItemFilterScope(filter))
+}
+
+// Func to Get from Struct Model defined
+func (repo *InventoryItemRepository) GetByModel(where models.InventoryItem) (*models.InventoryItem, error) {
+	var item models.InventoryItem
+	_, err := First(&where, &item, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &item, err
+}
+
+// Func to get the on-hand balance of inventory items, item without movement has zero balance
+func (repo *InventoryItemRepository) GetBalances(itemIds []uint) (map[uint]float64, error) {
+	balances := make(map[uint]float64, len(itemIds))
+	if len(itemIds) == 0 {
+		return balances, nil
+	}
+
+	var rows []InventoryBalance
+	err := db.GetDB().Model(&models.StockMovement{}).
+		Select("item_id, SUM(quantity) AS on_hand").
+		Where("item_id IN ?", itemIds).
+		Group("item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, itemId := range itemIds {
+		balances[itemId] = 0
+	}
+	for _, row := range rows {
+		balances[row.ItemId] = row.OnHand
+	}
+	return balances, nil
+}
+
+// Func to Update Inventory Item
+func (repo *InventoryItemRepository) Update(item *models.InventoryItem) error {
+	return Save(item)
+}
+
+// Func to Delete Inventory Item by Model defined in handler
+func (repo *InventoryItemRepository) Delete(item *models.InventoryItem) error {
+	_, err := DeleteByModel(item)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/aggregate"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm/clause"
+)
+
+var measurementRepository *MeasurementRepository
+
+// Fields of measurement that can be used for sorting
+var MeasurementSortableFields = []string{"id", "measured_at", "device_id"}
+
+// Number of rows inserted in single statement when storing batch of measurements
+const measurementInsertBatchSize = 500
+
+// Number of aggregated buckets kept in memory, a weekly chart of hourly bucket is 168 buckets
+const measurementAggregateCacheCapacity = 100000
+
+// Time aggregated bucket is kept in memory. New measurement only invalidate the cache of this instance,
+// so the other instances serve the stale bucket for at most this long
+const measurementAggregateCacheTtl = 5 * time.Minute
+
+// Filter for listing measurements, zero value means no filter
+type MeasurementFilter struct {
+	DeviceId uint
+	From     time.Time
+	To       time.Time
+}
+
+type MeasurementRepository struct {
+	aggregateCache *aggregate.Cache
+}
+
+type MeasurementRepositoryInterface interface {
+	CreateBatch(measurements []models.Measurement) ([]models.Measurement, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter MeasurementFilter) (*helpers.Pagination, error)
+	Aggregate(pondId uint, query aggregate.Query) ([]aggregate.Bucket, error)
+}
+
+// Func to return Measurement Repository instance
+func GetMeasurementRepository() MeasurementRepositoryInterface {
+	if measurementRepository == nil {
+		measurementRepository = &MeasurementRepository{
+			aggregateCache: aggregate.NewCache(measurementAggregateCacheCapacity, measurementAggregateCacheTtl),
+		}
+	}
+	return measurementRepository
+}
+
+// Func to store batch of Measurement, measurement that already exist on the same device and timestamp is skipped.
+// Return the stored measurements, so only they are evaluated afterwards
+func (repo *MeasurementRepository) CreateBatch(measurements []models.Measurement) ([]models.Measurement, error) {
+	newMeasurements, err := withoutStoredMeasurements(measurements)
+	if err != nil || len(newMeasurements) == 0 {
+		return newMeasurements, err
+	}
+
+	// Measurement stored meanwhile by another request is still skipped by the unique index
+	err = db.GetDB().Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(&newMeasurements, measurementInsertBatchSize).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Cached bucket that cover the new measurements is no longer valid
+	for pondId, timeRange := range measurementTimeRanges(newMeasurements) {
+		repo.aggregateCache.Invalidate(pondId, timeRange[0], timeRange[1])
+	}
+	return newMeasurements, nil
+}
+
+// Helper to drop measurement that is already stored, or repeated in the batch, on the same device and timestamp
+func withoutStoredMeasurements(measurements []models.Measurement) ([]models.Measurement, error) {
+	if len(measurements) == 0 {
+		return []models.Measurement{}, nil
+	}
+
+	deviceIds := make([]uint, 0, len(measurements))
+	measuredAts := make([]time.Time, 0, len(measurements))
+	for _, measurement := range measurements {
+		deviceIds = append(deviceIds, measurement.DeviceId)
+		measuredAts = append(measuredAts, measurement.MeasuredAt)
+	}
+
+	var storedMeasurements []models.Measurement
+	err := db.GetDB().Model(&models.Measurement{}).Select("device_id", "measured_at").
+		Where("device_id IN ? AND measured_at IN ?", deviceIds, measuredAts).
+		Find(&storedMeasurements).Error
+	if err != nil {
+		return nil, err
+	}
+
+	key := func(measurement models.Measurement) string {
+		return fmt.Sprintf("%d/%d", measurement.DeviceId, measurement.MeasuredAt.UnixNano())
+	}
+	stored := make(map[string]bool, len(storedMeasurements))
+	for _, measurement := range storedMeasurements {
+		stored[key(measurement)] = true
+	}
+
+	newMeasurements := make([]models.Measurement, 0, len(measurements))
+	for _, measurement := range measurements {
+		if stored[key(measurement)] {
+			continue
+		}
+		stored[key(measurement)] = true
+		newMeasurements = append(newMeasurements, measurement)
+	}
+	return newMeasurements, nil
+}
+
+// Func to get All Measurement of Pond with Pagination, device and time range
+func (repo *MeasurementRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter MeasurementFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(MeasurementSortableFields); err != nil {
+		return nil, err
+	}
+
+	var measurements []models.Measurement
+	where := &models.Measurement{PondId: pondId, DeviceId: filter.DeviceId}
+	return Query(where, &measurements, pagination, []string{}, TimeRangeScope("measured_at", filter.From, filter.To))
+}
+
+// Func to aggregate the parameter of Measurement of Pond into time buckets, computed in the database.
+// Completed bucket is cached, so only the bucket that is not cached yet is queried
+func (repo *MeasurementRepository) Aggregate(pondId uint, query aggregate.Query) ([]aggregate.Bucket, error) {
+	starts := query.Buckets()
+	buckets := make([]aggregate.Bucket, len(starts))
+
+	// Range of buckets that is not cached, queried at once
+	first, last := -1, -1
+	for i, start := range starts {
+		if cached, ok := repo.aggregateCache.Get(pondId, query, start); ok {
+			buckets[i] = cached
+			continue
+		}
+		buckets[i] = aggregate.Bucket{Start: start}
+		if first == -1 {
+			first = i
+		}
+		last = i
+	}
+	if first == -1 {
+		return buckets, nil
+	}
+
+	from := starts[first]
+	to := starts[last].Add(query.Interval)
+	if to.After(query.To) {
+		to = query.To
+	}
+	rows, err := queryBuckets(pondId, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		i := int(time.Unix(row.Bucket, 0).Sub(query.From) / query.Interval)
+		if i < first || i > last {
+			continue
+		}
+		buckets[i].Value = row.Value
+		buckets[i].Count = row.Count
+	}
+
+	// Only bucket that is already over (and fully requested) will not change anymore
+	now := time.Now()
+	for i := first; i <= last; i++ {
+		end := buckets[i].Start.Add(query.Interval)
+		if !end.After(now) && !end.After(query.To) {
+			repo.aggregateCache.Set(pondId, query, buckets[i])
+		}
+	}
+	return buckets, nil
+}
+
+// Row of aggregated bucket, Bucket is the unix time of the bucket start
+type measurementBucketRow struct {
+	Bucket int64
+	Value  *float64
+	Count  int64
+}
+
+// Helper to aggregate measurements into buckets in the database,
+// the bucket expression depends on the driver of the database
+func queryBuckets(pondId uint, query aggregate.Query, from, to time.Time) ([]measurementBucketRow, error) {
+	database := db.GetDB()
+	seconds := int64(query.Interval / time.Second)
+
+	var bucket string
+	switch database.Dialector.Name() {
+	// The mysql session and the stored datetime are in UTC (see db.SetupDB), so UNIX_TIMESTAMP does not shift the bucket
+	case "mysql":
+		bucket = fmt.Sprintf("CAST(FLOOR(UNIX_TIMESTAMP(measured_at) / %d) * %d AS SIGNED)", seconds, seconds)
+	case "postgres":
+		bucket = fmt.Sprintf("CAST(FLOOR(EXTRACT(EPOCH FROM measured_at) / %d) * %d AS BIGINT)", seconds, seconds)
+	default:
+		return nil, fmt.Errorf("aggregation is not supported on %s database", database.Dialector.Name())
+	}
+
+	// Parameter and function are whitelisted by the aggregate query
+	var rows []measurementBucketRow
+	err := database.Model(&models.Measurement{}).
+		Select(fmt.Sprintf("%s AS bucket, %s(%s) AS value, COUNT(%s) AS count", bucket, query.Function, query.Parameter, query.Parameter)).
+		Where("pond_id = ? AND measured_at >= ? AND measured_at < ?", pondId, from, to).
+		Where(query.Parameter + " IS NOT NULL").
+		Group("bucket").
+		Order("bucket").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// Helper to get the earliest and latest time of measurements per pond
+func measurementTimeRanges(measurements []models.Measurement) map[uint][2]time.Time {
+	timeRanges := map[uint][2]time.Time{}
+	for _, measurement := range measurements {
+		timeRange, ok := timeRanges[measurement.PondId]
+		if !ok || measurement.MeasuredAt.Before(timeRange[0]) {
+			timeRange[0] = measurement.MeasuredAt
+		}
+		if !ok || measurement.MeasuredAt.After(timeRange[1]) {
+			timeRange[1] = measurement.MeasuredAt
+		}
+		timeRanges[measurement.PondId] = timeRange
+	}
+	return timeRanges
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+var mortalityLogRepository *MortalityLogRepository
+
+// Fields of mortality log that can be used for sorting
+var MortalityLogSortableFields = []string{"id", "recorded_at", "count", "created_at"}
+
+// Filter for listing mortality logs, zero value means not filtered
+type MortalityLogFilter struct {
+	CycleId uint
+	From    time.Time
+	To      time.Time
+}
+
+type MortalityLogRepository struct {
+}
+
+type MortalityLogRepositoryInterface interface {
+	Create(mortalityLog models.MortalityLog) (models.MortalityLog, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter MortalityLogFilter) (*helpers.Pagination, error)
+	GetAllByCycle(cycleId uint) (*[]models.MortalityLog, error)
+	GetByModel(where models.MortalityLog) (*models.MortalityLog, error)
+	SumByCycle(cycleId uint) ([]models.MortalityTotal, error)
+	Delete(mortalityLog *models.MortalityLog) error
+}
+
+// Func to return Mortality Log Repository instance
+func GetMortalityLogRepository() MortalityLogRepositoryInterface {
+	if mortalityLogRepository == nil {
+		mortalityLogRepository = &MortalityLogRepository{}
+	}
+	return mortalityLogRepository
+}
+
+// Func to Create Mortality Log
+func (repo *MortalityLogRepository) Create(mortalityLog models.MortalityLog) (models.MortalityLog, error) {
+	err := Create(&mortalityLog)
+	if err != nil {
+		return models.MortalityLog{}, err
+	}
+	return mortalityLog, nil
+}
+
+// Func to get All Mortality Log of Pond with Pagination, time range and cycle
+func (repo *MortalityLogRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter MortalityLogFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(MortalityLogSortableFields); err != nil {
+		return nil, err
+	}
+
+	var mortalityLogs []models.MortalityLog
+	where := &models.MortalityLog{PondId: pondId, CycleId: filter.CycleId}
+	return Query(where, &mortalityLogs, pagination, []string{}, TimeRangeScope("recorded_at", filter.From, filter.To))
+}
+
+// Func to get All Mortality Log of Cycle, the oldest first
+func (repo *MortalityLogRepository) GetAllByCycle(cycleId uint) (*[]models.MortalityLog, error) {
+	var mortalityLogs []models.MortalityLog
+	err := Find(&models.MortalityLog{CycleId: cycleId}, &mortalityLogs, []string{}, OrderBy("recorded_at asc"), OrderBy("id asc"))
+	return &mortalityLogs, err
+}
+
+// Func to Get from Struct Model defined
+func (repo *MortalityLogRepository) GetByModel(where models.MortalityLog) (*models.MortalityLog, error) {
+	var mortalityLog models.MortalityLog
+	_, err := First(&where, &mortalityLog, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &mortalityLog, err
+}
+
+// Func to sum the dead stock of cycle, grouped by suspected cause
+func (repo *MortalityLogRepository) SumByCycle(cycleId uint) ([]models.MortalityTotal, error) {
+	var totals []models.MortalityTotal
+	err := db.GetDB().Model(&models.MortalityLog{}).
+		Select("cause, SUM(count) AS count").
+		Where("cycle_id = ?", cycleId).
+		Group("cause").
+		Order("cause asc").
+		Scan(&totals).Error
+	return totals, err
+}
+
+// Func to Delete Mortality Log by Model defined in handler
+func (repo *MortalityLogRepository) Delete(mortalityLog *models.MortalityLog) error {
+	_, err := DeleteByModel(mortalityLog)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+var organizationRepository *OrganizationRepository
+
+type OrganizationRepository struct {
+}
+
+type OrganizationRepositoryInterface interface {
+	Create(organization models.Organization) (models.Organization, error)
+	GetAllByUser(userId uint) (*[]models.Organization, error)
+	GetById(organizationId string) (*models.Organization, error)
+	AddMember(organization *models.Organization, user *models.User) error
+}
+
+// Func to return Organization Repository instance
+func GetOrganizationRepository() OrganizationRepositoryInterface {
+	if organizationRepository == nil {
+		organizationRepository = &OrganizationRepository{}
+	}
+	return organizationRepository
+}
+
+// Func to Create Organization
+func (repo *OrganizationRepository) Create(organization models.Organization) (models.Organization, error) {
+	err := Create(&organization)
+	if err != nil {
+		return models.Organization{}, err
+	}
+	return organization, nil
+}
+
+// Func to Get All Organization where the user is member of
+func (repo *OrganizationRepository) GetAllByUser(userId uint) (*[]models.Organization, error) {
+	var organizations []models.Organization
+	err := db.GetDB().
+		Joins("JOIN user_organizations ON user_organizations.organization_id = organizations.id").
+		Where("user_organizations.user_id = ?", userId).
+		Order("organizations.id asc").
+		Find(&organizations).Error
+	return &organizations, err
+}
+
+// Func to Get Organization by Id
+func (repo *OrganizationRepository) GetById(organizationId string) (*models.Organization, error) {
+	var organization models.Organization
+	where := models.Organization{}
+	where.ID, _ = helpers.ParseUint(organizationId)
+	_, err := First(&where, &organization, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// Func to Add User as member of Organization
+func (repo *OrganizationRepository) AddMember(organization *models.Organization, user *models.User) error {
+	return db.GetDB().Model(organization).Association("Users").Append(user)
+}
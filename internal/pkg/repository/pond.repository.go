@@ -1,18 +1,38 @@
 package repository
 
 import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
 )
 
 var pondRepository *PondRepository
 
+// Fields of pond that can be used for sorting
+var PondSortableFields = []string{"id", "name", "farm_id", "area", "created_at", "updated_at"}
+
+// Filter for listing ponds
+type PondFilter struct {
+	Name   string
+	FarmId uint
+	Type   string
+	Status string
+}
+
+// Pond Repository. If "scoped" is true, every query is limited to
+// ponds whose farm belong to one of the OrganizationIds (tenant)
 type PondRepository struct {
+	OrganizationIds []uint
+	scoped          bool
 }
 
 type PondRepositoryInterface interface {
+	WithTenant(organizationIds []uint) PondRepositoryInterface
 	Create(pond models.Pond) (models.Pond, error)
 	GetAll() (*[]models.Pond, error)
+	GetAllPaginated(pagination helpers.Pagination, filter PondFilter) (*helpers.Pagination, error)
+	CountByStatus() (map[string]int64, error)
 	GetById(pondId string) (*models.Pond, error)
 	GetByModel(where models.Pond) (*models.Pond, error)
 	Update(pond *models.Pond) error
@@ -27,6 +47,30 @@ func GetPondRepository() PondRepositoryInterface {
 	return pondRepository
 }
 
+// Func to return new Pond Repository scoped to the tenant
+func (repo *PondRepository) WithTenant(organizationIds []uint) PondRepositoryInterface {
+	return &PondRepository{
+		OrganizationIds: organizationIds,
+		scoped:          true,
+	}
+}
+
+// Func to return the tenant scope of the repository
+func (repo *PondRepository) scopes() []Scope {
+	if !repo.scoped {
+		return []Scope{}
+	}
+	return []Scope{PondTenantScope(repo.OrganizationIds)}
+}
+
+// Scope to limit ponds query to the tenant
+func PondTenantScope(organizationIds []uint) Scope {
+	return func(tx *gorm.DB) *gorm.DB {
+		tenantFarms := db.GetDB().Model(&models.Farm{}).Select("id").Where("organization_id IN ?", organizationIds)
+		return tx.Where("ponds.farm_id IN (?)", tenantFarms)
+	}
+}
+
 // Func to Create Pond
 func (repo *PondRepository) Create(pond models.Pond) (models.Pond, error) {
 	err := Create(&pond)
@@ -39,16 +83,54 @@ func (repo *PondRepository) Create(pond models.Pond) (models.Pond, error) {
 // Func to get All Pond without Pagination
 func (repo *PondRepository) GetAll() (*[]models.Pond, error) {
 	var ponds []models.Pond
-	err := Find(&models.Pond{}, &ponds, []string{"Farm"}, "id asc")
+	err := Find(&models.Pond{}, &ponds, []string{"Farm"}, append(repo.scopes(), OrderBy("id asc"))...)
 	return &ponds, err
 }
 
+// Func to Count Pond per status
+func (repo *PondRepository) CountByStatus() (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	err := db.GetDB().Model(&models.Pond{}).
+		Scopes(toGormScopes(repo.scopes())...).
+		Select("status, COUNT(*) AS count").
+		Group("status").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int64{}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+// Func to get All Pond with Pagination, sorting and filtering
+func (repo *PondRepository) GetAllPaginated(pagination helpers.Pagination, filter PondFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(PondSortableFields); err != nil {
+		return nil, err
+	}
+
+	scopes := repo.scopes()
+	if filter.Name != "" {
+		scopes = append(scopes, NameContainsScope("ponds.name", filter.Name))
+	}
+
+	var ponds []models.Pond
+	where := &models.Pond{FarmId: filter.FarmId, Type: filter.Type, Status: filter.Status}
+	return Query(where, &ponds, pagination, []string{"Farm"}, scopes...)
+}
+
 // Func to Get Pond by Id
 func (repo *PondRepository) GetById(pondId string) (*models.Pond, error) {
 	var pond models.Pond
 	where := models.Pond{}
 	where.ID, _ = helpers.ParseUint(pondId)
-	_, err := First(&where, &pond, []string{"Farm"})
+	_, err := First(&where, &pond, []string{"Farm"}, repo.scopes()...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +140,7 @@ func (repo *PondRepository) GetById(pondId string) (*models.Pond, error) {
 // Func to Get from Struct Model defined
 func (repo *PondRepository) GetByModel(where models.Pond) (*models.Pond, error) {
 	var pond models.Pond
-	_, err := First(&where, &pond, []string{})
+	_, err := First(&where, &pond, []string{}, repo.scopes()...)
 	if err != nil {
 		return nil, err
 	}
@@ -67,12 +149,12 @@ func (repo *PondRepository) GetByModel(where models.Pond) (*models.Pond, error)
 
 // Func to Update Pond by Model defined in handler
 func (repo *PondRepository) Update(pond *models.Pond) error {
-	return Save(pond)
+	return Save(pond, repo.scopes()...)
 }
 
 // Func to Delete Pond by Model defined in handler
 func (repo *PondRepository) Delete(pond *models.Pond) error {
-	_, err := DeleteByModel(pond)
+	_, err := DeleteByModel(pond, repo.scopes()...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"gorm.io/gorm"
+)
+
+var pondThresholdRepository *PondThresholdRepository
+
+type PondThresholdRepository struct {
+}
+
+type PondThresholdRepositoryInterface interface {
+	GetAllByPond(pondId uint) (*[]models.PondThreshold, error)
+	GetByModel(where models.PondThreshold) (*models.PondThreshold, error)
+	Upsert(threshold models.PondThreshold) (models.PondThreshold, error)
+}
+
+// Func to return Pond Threshold Repository instance
+func GetPondThresholdRepository() PondThresholdRepositoryInterface {
+	if pondThresholdRepository == nil {
+		pondThresholdRepository = &PondThresholdRepository{}
+	}
+	return pondThresholdRepository
+}
+
+// Func to get All Threshold configured on Pond
+func (repo *PondThresholdRepository) GetAllByPond(pondId uint) (*[]models.PondThreshold, error) {
+	var thresholds []models.PondThreshold
+	err := Find(&models.PondThreshold{PondId: pondId}, &thresholds, []string{}, OrderBy("parameter asc"))
+	return &thresholds, err
+}
+
+// Func to Get from Struct Model defined
+func (repo *PondThresholdRepository) GetByModel(where models.PondThreshold) (*models.PondThreshold, error) {
+	var threshold models.PondThreshold
+	_, err := First(&where, &threshold, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &threshold, err
+}
+
+// Func to Create or Replace the Threshold of pond parameter
+func (repo *PondThresholdRepository) Upsert(threshold models.PondThreshold) (models.PondThreshold, error) {
+	existedThreshold, err := repo.GetByModel(models.PondThreshold{PondId: threshold.PondId, Parameter: threshold.Parameter})
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.PondThreshold{}, err
+	}
+
+	if existedThreshold == nil {
+		err = Create(&threshold)
+		if err != nil {
+			return models.PondThreshold{}, err
+		}
+		return threshold, nil
+	}
+
+	// Replace every bound, including nil one (removing the bound)
+	threshold.ID = existedThreshold.ID
+	threshold.CreatedAt = existedThreshold.CreatedAt
+	err = Update(existedThreshold, map[string]interface{}{
+		"min":          threshold.Min,
+		"max":          threshold.Max,
+		"critical_min": threshold.CriticalMin,
+		"critical_max": threshold.CriticalMax,
+	})
+	if err != nil {
+		return models.PondThreshold{}, err
+	}
+	return threshold, nil
+}
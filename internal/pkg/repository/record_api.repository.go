@@ -1,17 +1,47 @@
 package repository
 
-import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
 
 var recordApiRepository RecordApiRepositoryInterface
 
+// Fields of record api that can be used for sorting
+var RecordApiSortableFields = []string{"id", "bucket_start", "route", "status", "count", "latency_max_ms", "created_at", "updated_at"}
+
+// Fields that record api can be grouped by
+const (
+	RecordApiGroupByRoute  = "route"
+	RecordApiGroupByStatus = "status"
+)
+
+// Number of rows upserted in single statement, a row binds 23 parameters and a statement can bind at most 65535
+const recordApiUpsertBatchSize = 1000
+
+// Filter for listing record api, zero value means not filtered
+type RecordApiFilter struct {
+	From time.Time
+	To   time.Time
+}
+
 type RecordApiRepository struct {
 }
 
 type RecordApiRepositoryInterface interface {
 	Create(record models.RecordApi)
 	GetAll() (*[]models.RecordApi, error)
+	GetAllPaginated(pagination helpers.Pagination, filter RecordApiFilter) (*helpers.Pagination, error)
+	GetGrouped(groupBy string, filter RecordApiFilter) ([]models.RecordApiGroup, error)
 	GetByModel(where models.RecordApi) (*models.RecordApi, error)
-	UpdateCount(record *models.RecordApi) error
+	IncrementCounts(records []models.RecordApi) error
 }
 
 // Func to return instance of Record Api Interface
@@ -30,10 +60,52 @@ func (repo *RecordApiRepository) Create(record models.RecordApi) {
 // Func to Get All Record
 func (repo *RecordApiRepository) GetAll() (*[]models.RecordApi, error) {
 	var records []models.RecordApi
-	err := Find(&models.RecordApi{}, &records, []string{}, "request_path asc")
+	err := Find(&models.RecordApi{}, &records, []string{}, OrderBy("bucket_start asc, route asc"))
 	return &records, err
 }
 
+// Func to Get All Record with Pagination (offset or cursor) and time range of the bucket
+func (repo *RecordApiRepository) GetAllPaginated(pagination helpers.Pagination, filter RecordApiFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(RecordApiSortableFields); err != nil {
+		return nil, err
+	}
+
+	var records []models.RecordApi
+	return Query(&models.RecordApi{}, &records, pagination, []string{}, TimeRangeScope("bucket_start", filter.From, filter.To))
+}
+
+// Func to Get Record summed per route or status in the time range of the bucket, the busiest first
+func (repo *RecordApiRepository) GetGrouped(groupBy string, filter RecordApiFilter) ([]models.RecordApiGroup, error) {
+	var groupColumns string
+	switch groupBy {
+	case RecordApiGroupByRoute:
+		groupColumns = "method, route"
+	case RecordApiGroupByStatus:
+		groupColumns = "status"
+	default:
+		return nil, fmt.Errorf("record api can not be grouped by %s", groupBy)
+	}
+
+	selects := []string{
+		groupColumns,
+		"SUM(count) AS count",
+		"SUM(latency_sum_ms) AS latency_sum_ms",
+		"MAX(latency_max_ms) AS latency_max_ms",
+	}
+	for _, column := range models.LatencyHistogramColumns {
+		selects = append(selects, fmt.Sprintf("SUM(%s) AS %s", column, column))
+	}
+
+	var groups []models.RecordApiGroup
+	err := db.GetDB().Model(&models.RecordApi{}).
+		Select(strings.Join(selects, ", ")).
+		Scopes(TimeRangeScope("bucket_start", filter.From, filter.To)).
+		Group(groupColumns).
+		Order("count desc, " + groupColumns).
+		Scan(&groups).Error
+	return groups, err
+}
+
 // Func to Get from Model
 func (repo *RecordApiRepository) GetByModel(where models.RecordApi) (*models.RecordApi, error) {
 	var recordApi models.RecordApi
@@ -44,8 +116,37 @@ func (repo *RecordApiRepository) GetByModel(where models.RecordApi) (*models.Rec
 	return &recordApi, err
 }
 
-// Func to Update the Count
-func (repo *RecordApiRepository) UpdateCount(record *models.RecordApi) error {
-	record.Count++
-	return Save(record)
+// Func to add the Count and latency of records in single upsert, the record is created when it does not exist.
+// The counters are added by the database so concurrent writers do not lose increments
+func (repo *RecordApiRepository) IncrementCounts(records []models.RecordApi) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	database := db.GetDB()
+	var inserted string
+	switch database.Dialector.Name() {
+	case "mysql":
+		inserted = "VALUES(%s)"
+	case "postgres":
+		inserted = "excluded.%s"
+	default:
+		return fmt.Errorf("upsert is not supported on %s database", database.Dialector.Name())
+	}
+
+	current := func(column string) clause.Column {
+		return clause.Column{Table: clause.CurrentTable, Name: column}
+	}
+	assignments := map[string]interface{}{
+		"latency_max_ms": gorm.Expr("GREATEST(?, "+fmt.Sprintf(inserted, "latency_max_ms")+")", current("latency_max_ms")),
+		"updated_at":     gorm.Expr(fmt.Sprintf(inserted, "updated_at")),
+	}
+	for _, column := range append([]string{"count", "latency_sum_ms"}, models.LatencyHistogramColumns...) {
+		assignments[column] = gorm.Expr("? + "+fmt.Sprintf(inserted, column), current(column))
+	}
+
+	return database.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bucket_start"}, {Name: "method"}, {Name: "route"}, {Name: "status"}, {Name: "user_agent"}},
+		DoUpdates: clause.Assignments(assignments),
+	}).CreateInBatches(&records, recordApiUpsertBatchSize).Error
 }
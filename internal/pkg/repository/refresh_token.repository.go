@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var refreshTokenRepository *RefreshTokenRepository
+
+type RefreshTokenRepository struct {
+}
+
+type RefreshTokenRepositoryInterface interface {
+	Create(refreshToken models.RefreshToken) (models.RefreshToken, error)
+	GetByHash(tokenHash string) (*models.RefreshToken, error)
+	Revoke(refreshToken *models.RefreshToken) (bool, error)
+	RevokeAllByUser(userId uint) error
+}
+
+// Func to return Refresh Token Repository instance
+func GetRefreshTokenRepository() RefreshTokenRepositoryInterface {
+	if refreshTokenRepository == nil {
+		refreshTokenRepository = &RefreshTokenRepository{}
+	}
+	return refreshTokenRepository
+}
+
+// Func to Create Refresh Token
+func (repo *RefreshTokenRepository) Create(refreshToken models.RefreshToken) (models.RefreshToken, error) {
+	err := Create(&refreshToken)
+	if err != nil {
+		return models.RefreshToken{}, err
+	}
+	return refreshToken, nil
+}
+
+// Func to Get Refresh Token by its hash
+func (repo *RefreshTokenRepository) GetByHash(tokenHash string) (*models.RefreshToken, error) {
+	var refreshToken models.RefreshToken
+	_, err := First(&models.RefreshToken{TokenHash: tokenHash}, &refreshToken, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &refreshToken, nil
+}
+
+// Func to Revoke single Refresh Token, return false if it is already revoked.
+// The update is conditional, so only one of the concurrent revokes of the same token succeed
+func (repo *RefreshTokenRepository) Revoke(refreshToken *models.RefreshToken) (bool, error) {
+	now := time.Now()
+	result := db.GetDB().Model(&models.RefreshToken{}).
+		Where("id = ? AND revoked_at IS NULL", refreshToken.ID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected != 1 {
+		return false, nil
+	}
+	refreshToken.RevokedAt = &now
+	return true, nil
+}
+
+// Func to Revoke every active Refresh Token owned by user
+func (repo *RefreshTokenRepository) RevokeAllByUser(userId uint) error {
+	return db.GetDB().Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var revokedTokenRepository *RevokedTokenRepository
+
+type RevokedTokenRepository struct {
+}
+
+type RevokedTokenRepositoryInterface interface {
+	Create(revokedToken models.RevokedToken) error
+	IsRevoked(jti string) (bool, error)
+	DeleteExpired() error
+}
+
+// Func to return Revoked Token Repository instance
+func GetRevokedTokenRepository() RevokedTokenRepositoryInterface {
+	if revokedTokenRepository == nil {
+		revokedTokenRepository = &RevokedTokenRepository{}
+	}
+	return revokedTokenRepository
+}
+
+// Func to put access token into revocation list
+func (repo *RevokedTokenRepository) Create(revokedToken models.RevokedToken) error {
+	return Create(&revokedToken)
+}
+
+// Func to check whether the jti is in revocation list
+func (repo *RevokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	var count int64
+	err := db.GetDB().Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+// Func to clean up revocation list from token that is already expired
+func (repo *RevokedTokenRepository) DeleteExpired() error {
+	return db.GetDB().Unscoped().Where("expires_at < ?", time.Now()).Delete(&models.RevokedToken{}).Error
+}
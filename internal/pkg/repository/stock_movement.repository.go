@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var stockMovementRepository *StockMovementRepository
+
+// Error when the movement take more than the on-hand balance of the item
+var ErrInsufficientStock = errors.New("insufficient stock")
+
+// Fields of stock movement that can be used for sorting
+var StockMovementSortableFields = []string{"id", "moved_at", "quantity", "created_at"}
+
+// Filter for listing stock movements, zero value means not filtered
+type StockMovementFilter struct {
+	Type   string
+	PondId uint
+	From   time.Time
+	To     time.Time
+}
+
+type StockMovementRepository struct {
+}
+
+type StockMovementRepositoryInterface interface {
+	Create(movement models.StockMovement) (models.StockMovement, error)
+	GetAllByItem(itemId uint, pagination helpers.Pagination, filter StockMovementFilter) (*helpers.Pagination, error)
+}
+
+// Func to return Stock Movement Repository instance
+func GetStockMovementRepository() StockMovementRepositoryInterface {
+	if stockMovementRepository == nil {
+		stockMovementRepository = &StockMovementRepository{}
+	}
+	return stockMovementRepository
+}
+
+// Func to Create Stock Movement. The item is locked while the balance is checked,
+// so concurrent consumption can not take the stock below zero
+func (repo *StockMovementRepository) Create(movement models.StockMovement) (models.StockMovement, error) {
+	err := db.GetDB().Transaction(func(tx *gorm.DB) error {
+		var item models.InventoryItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&item, movement.ItemId).Error; err != nil {
+			return err
+		}
+
+		if movement.Quantity < 0 {
+			var onHand float64
+			err := tx.Model(&models.StockMovement{}).
+				Select("COALESCE(SUM(quantity), 0)").
+				Where("item_id = ?", movement.ItemId).
+				Scan(&onHand).Error
+			if err != nil {
+				return err
+			}
+			if onHand+movement.Quantity < 0 {
+				return ErrInsufficientStock
+			}
+		}
+		return tx.Create(&movement).Error
+	})
+	if err != nil {
+		return models.StockMovement{}, err
+	}
+	return movement, nil
+}
+
+// Func to get All Stock Movement of Inventory Item with Pagination and filter
+func (repo *StockMovementRepository) GetAllByItem(itemId uint, pagination helpers.Pagination, filter StockMovementFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(StockMovementSortableFields); err != nil {
+		return nil, err
+	}
+
+	var movements []models.StockMovement
+	where := &models.StockMovement{ItemId: itemId, Type: filter.Type}
+	if filter.PondId != 0 {
+		where.PondId = &filter.PondId
+	}
+	return Query(where, &movements, pagination, []string{}, TimeRangeScope("moved_at", filter.From, filter.To))
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"sort"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+var treatmentLogRepository *TreatmentLogRepository
+
+// Fields of treatment log that can be used for sorting
+var TreatmentLogSortableFields = []string{"id", "treated_at", "withdrawal_days", "created_at"}
+
+// Filter for listing treatment logs, zero value means not filtered
+type TreatmentLogFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+type TreatmentLogRepository struct {
+}
+
+type TreatmentLogRepositoryInterface interface {
+	Create(treatmentLog models.TreatmentLog) (models.TreatmentLog, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter TreatmentLogFilter) (*helpers.Pagination, error)
+	GetWithdrawingByPond(pondId uint, at time.Time) (*[]models.TreatmentLog, error)
+	GetByModel(where models.TreatmentLog) (*models.TreatmentLog, error)
+	Delete(treatmentLog *models.TreatmentLog) error
+}
+
+// Func to return Treatment Log Repository instance
+func GetTreatmentLogRepository() TreatmentLogRepositoryInterface {
+	if treatmentLogRepository == nil {
+		treatmentLogRepository = &TreatmentLogRepository{}
+	}
+	return treatmentLogRepository
+}
+
+// Func to Create Treatment Log
+func (repo *TreatmentLogRepository) Create(treatmentLog models.TreatmentLog) (models.TreatmentLog, error) {
+	err := Create(&treatmentLog)
+	if err != nil {
+		return models.TreatmentLog{}, err
+	}
+	return treatmentLog, nil
+}
+
+// Func to get All Treatment Log of Pond with Pagination and time range
+func (repo *TreatmentLogRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter TreatmentLogFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(TreatmentLogSortableFields); err != nil {
+		return nil, err
+	}
+
+	var treatmentLogs []models.TreatmentLog
+	where := &models.TreatmentLog{PondId: pondId}
+	return Query(where, &treatmentLogs, pagination, []string{}, TimeRangeScope("treated_at", filter.From, filter.To))
+}
+
+// Func to get Treatment Log of Pond whose withdrawal period is not over at the time, the latest ending first.
+// Withdrawal period is bounded, so only treatment in the last MaxWithdrawalDays is fetched
+func (repo *TreatmentLogRepository) GetWithdrawingByPond(pondId uint, at time.Time) (*[]models.TreatmentLog, error) {
+	var treatmentLogs []models.TreatmentLog
+	err := db.GetDB().
+		Where("pond_id = ? AND withdrawal_days > 0", pondId).
+		Where("treated_at > ? AND treated_at <= ?", at.AddDate(0, 0, -models.MaxWithdrawalDays), at).
+		Find(&treatmentLogs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	withdrawing := make([]models.TreatmentLog, 0, len(treatmentLogs))
+	for _, treatmentLog := range treatmentLogs {
+		if treatmentLog.IsWithdrawing(at) {
+			withdrawing = append(withdrawing, treatmentLog)
+		}
+	}
+	sort.Slice(withdrawing, func(i, j int) bool {
+		return withdrawing[i].WithdrawalEndsAt().After(withdrawing[j].WithdrawalEndsAt())
+	})
+	return &withdrawing, nil
+}
+
+// Func to Get from Struct Model defined
+func (repo *TreatmentLogRepository) GetByModel(where models.TreatmentLog) (*models.TreatmentLog, error) {
+	var treatmentLog models.TreatmentLog
+	_, err := First(&where, &treatmentLog, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &treatmentLog, err
+}
+
+// Func to Delete Treatment Log by Model defined in handler
+func (repo *TreatmentLogRepository) Delete(treatmentLog *models.TreatmentLog) error {
+	_, err := DeleteByModel(treatmentLog)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+)
+
+var userRepository *UserRepository
+
+type UserRepository struct {
+}
+
+type UserRepositoryInterface interface {
+	Create(user models.User) (models.User, error)
+	GetById(userId string) (*models.User, error)
+	GetByModel(where models.User) (*models.User, error)
+	Update(user *models.User) error
+}
+
+// Func to return User Repository instance
+func GetUserRepository() UserRepositoryInterface {
+	if userRepository == nil {
+		userRepository = &UserRepository{}
+	}
+	return userRepository
+}
+
+// Func to Create User
+func (repo *UserRepository) Create(user models.User) (models.User, error) {
+	err := Create(&user)
+	if err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+// Func to Get User by Id (with its Organizations)
+func (repo *UserRepository) GetById(userId string) (*models.User, error) {
+	var user models.User
+	where := models.User{}
+	where.ID, _ = helpers.ParseUint(userId)
+	_, err := First(&where, &user, []string{"Organizations"})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Func to Get from Struct Model defined (with its Organizations)
+func (repo *UserRepository) GetByModel(where models.User) (*models.User, error) {
+	var user models.User
+	_, err := First(&where, &user, []string{"Organizations"})
+	if err != nil {
+		return nil, err
+	}
+	return &user, err
+}
+
+// Func to Update User by Model defined in handler
+func (repo *UserRepository) Update(user *models.User) error {
+	return Save(user)
+}
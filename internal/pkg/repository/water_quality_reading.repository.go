@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"gorm.io/gorm"
+)
+
+var waterQualityReadingRepository *WaterQualityReadingRepository
+
+// Fields of water quality reading that can be used for sorting
+var WaterQualityReadingSortableFields = []string{"id", "measured_at", "created_at"}
+
+// Filter for listing water quality readings, zero time means no bound
+type WaterQualityReadingFilter struct {
+	From time.Time
+	To   time.Time
+}
+
+type WaterQualityReadingRepository struct {
+}
+
+type WaterQualityReadingRepositoryInterface interface {
+	Create(reading models.WaterQualityReading) (models.WaterQualityReading, error)
+	GetAllByPond(pondId uint, pagination helpers.Pagination, filter WaterQualityReadingFilter) (*helpers.Pagination, error)
+	GetById(readingId string) (*models.WaterQualityReading, error)
+	GetByModel(where models.WaterQualityReading) (*models.WaterQualityReading, error)
+	Delete(reading *models.WaterQualityReading) error
+}
+
+// Func to return Water Quality Reading Repository instance
+func GetWaterQualityReadingRepository() WaterQualityReadingRepositoryInterface {
+	if waterQualityReadingRepository == nil {
+		waterQualityReadingRepository = &WaterQualityReadingRepository{}
+	}
+	return waterQualityReadingRepository
+}
+
+// Scope to limit query into time range of column
+func TimeRangeScope(column string, from, to time.Time) Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		if !from.IsZero() {
+			db = db.Where(column+" >= ?", from)
+		}
+		if !to.IsZero() {
+			db = db.Where(column+" <= ?", to)
+		}
+		return db
+	}
+}
+
+// Func to Create Water Quality Reading
+func (repo *WaterQualityReadingRepository) Create(reading models.WaterQualityReading) (models.WaterQualityReading, error) {
+	err := Create(&reading)
+	if err != nil {
+		return models.WaterQualityReading{}, err
+	}
+	return reading, nil
+}
+
+// Func to get All Water Quality Reading of Pond with Pagination and time range
+func (repo *WaterQualityReadingRepository) GetAllByPond(pondId uint, pagination helpers.Pagination, filter WaterQualityReadingFilter) (*helpers.Pagination, error) {
+	if err := pagination.ValidateSort(WaterQualityReadingSortableFields); err != nil {
+		return nil, err
+	}
+
+	var readings []models.WaterQualityReading
+	where := &models.WaterQualityReading{PondId: pondId}
+	return Query(where, &readings, pagination, []string{}, TimeRangeScope("measured_at", filter.From, filter.To))
+}
+
+// Func to Get Water Quality Reading by Id
+func (repo *WaterQualityReadingRepository) GetById(readingId string) (*models.WaterQualityReading, error) {
+	var reading models.WaterQualityReading
+	where := models.WaterQualityReading{}
+	where.ID, _ = helpers.ParseUint(readingId)
+	_, err := First(&where, &reading, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &reading, nil
+}
+
+// Func to Get from Struct Model defined
+func (repo *WaterQualityReadingRepository) GetByModel(where models.WaterQualityReading) (*models.WaterQualityReading, error) {
+	var reading models.WaterQualityReading
+	_, err := First(&where, &reading, []string{})
+	if err != nil {
+		return nil, err
+	}
+	return &reading, err
+}
+
+// Func to Delete Water Quality Reading by Model defined in handler
+func (repo *WaterQualityReadingRepository) Delete(reading *models.WaterQualityReading) error {
+	_, err := DeleteByModel(reading)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package telemetry
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Timestamp precision of line protocol, the default is nanosecond
+var precisions = map[string]time.Duration{
+	"":   time.Nanosecond,
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+}
+
+// Func to parse readings in line protocol format, one reading per line:
+//
+//	<measurement>[,<tag>=<value>...] <parameter>=<value>[,<parameter>=<value>...] <timestamp>
+//
+// The measurement name and tags are ignored since the device is already known from its api key.
+// Empty line and line starting with "#" are skipped. Parsed measurements are validated
+func ParseLineProtocol(reader io.Reader, precision string) ([]models.Measurement, error) {
+	unit, ok := precisions[precision]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown precision %q", ErrInvalidReading, precision)
+	}
+
+	var measurements []models.Measurement
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		measurement, err := parseLine(line, unit)
+		if err == nil {
+			err = Validate(measurement)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		measurements = append(measurements, measurement)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
+
+// Helper to parse single line of line protocol
+func parseLine(line string, unit time.Duration) (models.Measurement, error) {
+	var measurement models.Measurement
+
+	sections := strings.Fields(line)
+	if len(sections) != 3 {
+		return measurement, fmt.Errorf("%w: expected measurement, fields and timestamp", ErrInvalidReading)
+	}
+
+	for _, field := range strings.Split(sections[1], ",") {
+		keyValue := strings.SplitN(field, "=", 2)
+		if len(keyValue) != 2 {
+			return measurement, fmt.Errorf("%w: malformed field %q", ErrInvalidReading, field)
+		}
+
+		// Integer field is written with "i" suffix
+		value, err := strconv.ParseFloat(strings.TrimSuffix(keyValue[1], "i"), 64)
+		if err != nil {
+			return measurement, fmt.Errorf("%w: value of %s is not a number", ErrInvalidReading, keyValue[0])
+		}
+		if !measurement.SetParameter(keyValue[0], value) {
+			return measurement, fmt.Errorf("%w: unknown parameter %s", ErrInvalidReading, keyValue[0])
+		}
+	}
+
+	timestamp, err := strconv.ParseInt(sections[2], 10, 64)
+	if err != nil {
+		return measurement, fmt.Errorf("%w: timestamp is not an integer", ErrInvalidReading)
+	}
+	measurement.MeasuredAt = time.Unix(0, timestamp*int64(unit)).UTC()
+	return measurement, nil
+}
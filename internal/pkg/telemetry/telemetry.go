@@ -0,0 +1,50 @@
+package telemetry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Error when the reading posted by device is not valid
+var ErrInvalidReading = errors.New("invalid reading")
+
+// Physically plausible range of each parameter in aquaculture pond,
+// the same range used when reading is created manually
+var ParameterRanges = map[string][2]float64{
+	models.ParameterDissolvedOxygen: {0, 30},  // mg/L
+	models.ParameterPh:              {0, 14},  //
+	models.ParameterTemperature:     {-5, 50}, // celsius
+	models.ParameterSalinity:        {0, 70},  // ppt
+	models.ParameterAmmonia:         {0, 100}, // mg/L
+	models.ParameterNitrite:         {0, 100}, // mg/L
+}
+
+// Func to validate measurement posted by device,
+// it must have timestamp and at least one parameter, every parameter must be in its range
+func Validate(measurement models.Measurement) error {
+	if measurement.MeasuredAt.IsZero() {
+		return fmt.Errorf("%w: timestamp is required", ErrInvalidReading)
+	}
+
+	parameters := measurement.Measurements()
+	if len(parameters) == 0 {
+		return fmt.Errorf("%w: at least one parameter must be measured", ErrInvalidReading)
+	}
+
+	// Sorted so the reported parameter is deterministic
+	names := make([]string, 0, len(parameters))
+	for name := range parameters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		bound := ParameterRanges[name]
+		if value := parameters[name]; value < bound[0] || value > bound[1] {
+			return fmt.Errorf("%w: %s must be between %g and %g", ErrInvalidReading, name, bound[0], bound[1])
+		}
+	}
+	return nil
+}
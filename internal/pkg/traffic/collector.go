@@ -0,0 +1,239 @@
+package traffic
+
+import (
+	"log"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+)
+
+// Longest value of the key that is stored, the columns are varchar(255)
+const maxKeyLength = 255
+
+// Pending keys are limited to this times the max pending, so the memory is bounded while the database is down
+const pendingLimitFactor = 10
+
+var collector *Collector
+
+// Key of the traffic record, request to the same key in the same hour is counted together
+type Key struct {
+	BucketStart time.Time
+	Method      string
+	Route       string
+	Status      int
+	UserAgent   string
+}
+
+// Collector that count the traffic in memory and flush it to database periodically,
+// so the request does not wait for the database
+type Collector struct {
+	RecordApiRepository repository.RecordApiRepositoryInterface
+	FlushInterval       time.Duration
+	MaxPending          int
+
+	mutex   sync.Mutex
+	pending map[Key]*models.RecordApi
+	dropped uint
+	full    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	started bool
+	once    sync.Once
+}
+
+type CollectorInterface interface {
+	Start()
+	Stop()
+	Record(key Key, latency time.Duration)
+	Flush() error
+	TakeDropped() uint
+}
+
+// Func to create new Collector with the configuration
+func NewCollector(configuration config.RecordApiConfiguration) *Collector {
+	return &Collector{
+		RecordApiRepository: repository.GetRecordApiRepository(),
+		FlushInterval:       time.Duration(configuration.FlushIntervalSecond) * time.Second,
+		MaxPending:          configuration.MaxPending,
+		pending:             map[Key]*models.RecordApi{},
+		full:                make(chan struct{}, 1),
+		stop:                make(chan struct{}),
+		done:                make(chan struct{}),
+	}
+}
+
+// Func to get Collector instance that is shared by the middleware
+func GetCollector() *Collector {
+	if collector == nil {
+		collector = NewCollector(config.GetConfig().RecordApi)
+	}
+	return collector
+}
+
+// Func to start flushing in background, every flush interval or when pending keys reach the maximum
+func (collector *Collector) Start() {
+	collector.mutex.Lock()
+	collector.started = true
+	collector.mutex.Unlock()
+
+	go func() {
+		defer close(collector.done)
+		ticker := time.NewTicker(collector.FlushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				collector.flushAndLog()
+			case <-collector.full:
+				collector.flushAndLog()
+			case <-collector.stop:
+				collector.flushAndLog()
+				return
+			}
+		}
+	}()
+}
+
+// Func to stop flushing, the pending traffic is drained before it returns
+func (collector *Collector) Stop() {
+	collector.once.Do(func() {
+		collector.mutex.Lock()
+		started := collector.started
+		collector.mutex.Unlock()
+
+		if !started {
+			collector.flushAndLog()
+			return
+		}
+		close(collector.stop)
+		<-collector.done
+	})
+}
+
+// Func to count the request of the key and its latency, the bucket start is truncated to the hour
+func (collector *Collector) Record(key Key, latency time.Duration) {
+	key.BucketStart = key.BucketStart.UTC().Truncate(time.Hour)
+	key.Method = models.NormalizeMethod(key.Method)
+	key.Route = truncate(key.Route)
+	key.UserAgent = truncate(key.UserAgent)
+	latencyMs := float64(latency) / float64(time.Millisecond)
+
+	collector.mutex.Lock()
+	record, ok := collector.pending[key]
+	if !ok && collector.atLimit() {
+		collector.dropped++
+		collector.mutex.Unlock()
+		return
+	}
+	if !ok {
+		record = &models.RecordApi{
+			BucketStart: key.BucketStart,
+			Method:      key.Method,
+			Route:       key.Route,
+			Status:      key.Status,
+			UserAgent:   key.UserAgent,
+		}
+		collector.pending[key] = record
+	}
+	record.Count++
+	record.LatencySumMs += latencyMs
+	if latencyMs > record.LatencyMaxMs {
+		record.LatencyMaxMs = latencyMs
+	}
+	record.LatencyHistogram.Observe(latencyMs)
+	full := collector.MaxPending > 0 && len(collector.pending) >= collector.MaxPending
+	collector.mutex.Unlock()
+
+	// Flush early without blocking the request, one signal is enough
+	if full {
+		select {
+		case collector.full <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Func to write the pending traffic to database.
+// When it fails, the counts are kept to be written in the next flush
+func (collector *Collector) Flush() error {
+	collector.mutex.Lock()
+	pending := collector.pending
+	collector.pending = map[Key]*models.RecordApi{}
+	collector.mutex.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	records := make([]models.RecordApi, 0, len(pending))
+	for _, record := range pending {
+		records = append(records, *record)
+	}
+
+	if err := collector.RecordApiRepository.IncrementCounts(records); err != nil {
+		collector.mutex.Lock()
+		for key, record := range pending {
+			if existed, ok := collector.pending[key]; ok {
+				mergeRecord(existed, *record)
+			} else if collector.atLimit() {
+				collector.dropped++
+			} else {
+				collector.pending[key] = record
+			}
+		}
+		collector.mutex.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Func to get the number of records dropped since the last call, because the pending keys reach the limit
+func (collector *Collector) TakeDropped() uint {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	dropped := collector.dropped
+	collector.dropped = 0
+	return dropped
+}
+
+// Helper to check whether the pending keys reach the limit, the caller must hold the mutex
+func (collector *Collector) atLimit() bool {
+	return collector.MaxPending > 0 && len(collector.pending) >= collector.MaxPending*pendingLimitFactor
+}
+
+// Helper to flush in background, there is no caller to return the error to
+func (collector *Collector) flushAndLog() {
+	if err := collector.Flush(); err != nil {
+		log.Println("failed to flush api records:", err)
+	}
+	if dropped := collector.TakeDropped(); dropped > 0 {
+		log.Printf("dropped %d api records because the pending records reach the limit\n", dropped)
+	}
+}
+
+// Helper to add the counts of other record of the same key
+func mergeRecord(record *models.RecordApi, other models.RecordApi) {
+	record.Count += other.Count
+	record.LatencySumMs += other.LatencySumMs
+	if other.LatencyMaxMs > record.LatencyMaxMs {
+		record.LatencyMaxMs = other.LatencyMaxMs
+	}
+	record.LatencyHistogram.Add(other.LatencyHistogram)
+}
+
+// Helper to cut the value to the column length, without splitting multibyte character
+func truncate(value string) string {
+	if len(value) <= maxKeyLength {
+		return value
+	}
+	end := maxKeyLength
+	for end > 0 && !utf8.RuneStart(value[end]) {
+		end--
+	}
+	return value[:end]
+}
@@ -0,0 +1,45 @@
+package traffic
+
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+// Func to estimate the latency percentile (0 - 100) from the histogram, interpolated linearly inside the bucket.
+// The last bucket has no upper bound, so the max latency is used as its bound
+func Percentile(histogram models.LatencyHistogram, maxMs float64, percentile float64) float64 {
+	counts := histogram.Counts()
+	var total uint
+	for _, count := range counts {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := percentile / 100 * float64(total)
+	var cumulative float64
+	for i, count := range counts {
+		if count == 0 || cumulative+float64(count) < rank {
+			cumulative += float64(count)
+			continue
+		}
+
+		lower := 0.0
+		if i > 0 {
+			lower = models.LatencyBoundsMs[i-1]
+		}
+		upper := maxMs
+		if i < len(models.LatencyBoundsMs) {
+			upper = models.LatencyBoundsMs[i]
+		}
+		if upper < lower {
+			upper = lower
+		}
+
+		value := lower + (upper-lower)*(rank-cumulative)/float64(count)
+		// The latency never exceed the max that is observed
+		if maxMs > 0 && value > maxMs {
+			value = maxMs
+		}
+		return value
+	}
+	return maxMs
+}
@@ -0,0 +1,34 @@
+package validator
+
+// Struct that define the validator/binding of Upsert Pond Threshold Request
+type UpsertPondThresholdRequest struct {
+	Parameter   string   `json:"parameter" form:"parameter" binding:"required,oneof=dissolved_oxygen ph temperature salinity ammonia nitrite"`
+	Min         *float64 `json:"min" form:"min"`
+	Max         *float64 `json:"max" form:"max"`
+	CriticalMin *float64 `json:"critical_min" form:"critical_min"`
+	CriticalMax *float64 `json:"critical_max" form:"critical_max"`
+}
+
+// Func to check whether the bounds are ordered (critical min <= min <= max <= critical max)
+func (request UpsertPondThresholdRequest) IsOrdered() bool {
+	bounds := []*float64{request.CriticalMin, request.Min, request.Max, request.CriticalMax}
+	var previous *float64
+	for _, each := range bounds {
+		if each == nil {
+			continue
+		}
+		if previous != nil && *previous > *each {
+			return false
+		}
+		previous = each
+	}
+	return true
+}
+
+// Struct that define the binding of Get All Alert query
+type GetAllAlertRequest struct {
+	PaginationRequest
+	PondId   uint   `form:"pond_id"`
+	Status   string `form:"status" binding:"omitempty,oneof=open acknowledged resolved"`
+	Severity string `form:"severity" binding:"omitempty,oneof=warning critical"`
+}
@@ -0,0 +1,26 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Cycle Request
+type CreateCycleRequest struct {
+	Species              string    `json:"species" form:"species" binding:"required,min=1"`
+	StockingDate         time.Time `json:"stocking_date" form:"stocking_date" binding:"required"`
+	InitialCount         uint      `json:"initial_count" form:"initial_count" binding:"required,min=1"`
+	InitialAverageWeight float64   `json:"initial_average_weight" form:"initial_average_weight" binding:"gte=0"`
+	Status               string    `json:"status" form:"status" binding:"omitempty,oneof=planned active"`
+}
+
+// Struct that define the validator/binding of Update Cycle Request.
+// Only planned cycle can be updated
+type UpdateCycleRequest struct {
+	Species              string    `json:"species" form:"species"`
+	StockingDate         time.Time `json:"stocking_date" form:"stocking_date"`
+	InitialCount         uint      `json:"initial_count" form:"initial_count"`
+	InitialAverageWeight float64   `json:"initial_average_weight" form:"initial_average_weight" binding:"gte=0"`
+}
+
+// Struct that define the validator/binding of Change Cycle Status Request
+type ChangeCycleStatusRequest struct {
+	Status string `json:"status" form:"status" binding:"required,oneof=active failed"`
+}
@@ -0,0 +1,65 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Struct that define the validator/binding of Create Device Request
+type CreateDeviceRequest struct {
+	Name string `json:"name" form:"name" binding:"required"`
+}
+
+// Struct that define the validator/binding of single reading sent to ingest endpoint.
+// Range of each parameter is validated together with line protocol readings
+type IngestReadingRequest struct {
+	MeasuredAt      time.Time `json:"measured_at" binding:"required"`
+	DissolvedOxygen *float64  `json:"dissolved_oxygen"`
+	Ph              *float64  `json:"ph"`
+	Temperature     *float64  `json:"temperature"`
+	Salinity        *float64  `json:"salinity"`
+	Ammonia         *float64  `json:"ammonia"`
+	Nitrite         *float64  `json:"nitrite"`
+}
+
+// Func to convert the reading into measurement
+func (request IngestReadingRequest) ToMeasurement() models.Measurement {
+	return models.Measurement{
+		MeasuredAt:      request.MeasuredAt,
+		DissolvedOxygen: request.DissolvedOxygen,
+		Ph:              request.Ph,
+		Temperature:     request.Temperature,
+		Salinity:        request.Salinity,
+		Ammonia:         request.Ammonia,
+		Nitrite:         request.Nitrite,
+	}
+}
+
+// Struct that define the validator/binding of batched readings sent to ingest endpoint (JSON)
+type IngestRequest struct {
+	Readings []IngestReadingRequest `json:"readings" binding:"required,min=1,dive"`
+}
+
+// Struct that define the binding of ingest query
+type IngestQueryRequest struct {
+	Precision string `form:"precision"`
+}
+
+// Struct that define the binding of Get All Measurement query
+type GetAllMeasurementRequest struct {
+	PaginationRequest
+	DeviceId uint      `form:"device_id"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Struct that define the binding of Aggregate Reading query.
+// Metric, function and interval are validated by the aggregate query
+type AggregateReadingRequest struct {
+	Metric   string    `form:"metric" binding:"required"`
+	Interval string    `form:"interval" binding:"required"`
+	Fn       string    `form:"fn"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
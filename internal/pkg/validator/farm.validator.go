@@ -1,12 +1,40 @@
 package validator
 
-// Struct that define the binding of Create Farm Request
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+// Struct that define the binding of Create Farm Request.
+// Latitude and longitude must be given together
 type CreateFarmRequest struct {
-	Name string `json:"name" form:"name" binding:"required,min=1"`
+	Name           string         `json:"name" form:"name" binding:"required,min=1"`
+	OrganizationId uint           `json:"organization_id" form:"organization_id"`
+	Latitude       *float64       `json:"latitude" form:"latitude" binding:"omitempty,gte=-90,lte=90"`
+	Longitude      *float64       `json:"longitude" form:"longitude" binding:"omitempty,gte=-180,lte=180"`
+	Boundary       models.Polygon `json:"boundary" form:"boundary"`
 }
 
 // Struct that define the validator/binding of Update Farm Request
 type UpdateFarmRequest struct {
-	ID   uint   `json:"id" form:"id"`
-	Name string `json:"name" form:"name"`
+	ID             uint           `json:"id" form:"id"`
+	Name           string         `json:"name" form:"name"`
+	OrganizationId uint           `json:"organization_id" form:"organization_id"`
+	Latitude       *float64       `json:"latitude" form:"latitude" binding:"omitempty,gte=-90,lte=90"`
+	Longitude      *float64       `json:"longitude" form:"longitude" binding:"omitempty,gte=-180,lte=180"`
+	Boundary       models.Polygon `json:"boundary" form:"boundary"`
+}
+
+// Func to check that location is given completely (both or none)
+func (request CreateFarmRequest) HasCompleteLocation() bool {
+	return (request.Latitude == nil) == (request.Longitude == nil)
+}
+
+// Func to check that location is given completely (both or none)
+func (request UpdateFarmRequest) HasCompleteLocation() bool {
+	return (request.Latitude == nil) == (request.Longitude == nil)
+}
+
+// Struct that define the binding of Get All Farm query
+type GetAllFarmRequest struct {
+	PaginationRequest
+	Name string `form:"name"`
+	Bbox string `form:"bbox"`
 }
@@ -0,0 +1,26 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Feeding Log Request, quantity is in kilogram
+type CreateFeedingLogRequest struct {
+	FeedType string     `json:"feed_type" form:"feed_type" binding:"required,max=100"`
+	Quantity float64    `json:"quantity" form:"quantity" binding:"required,gt=0"`
+	FedAt    *time.Time `json:"fed_at" form:"fed_at"`
+}
+
+// Struct that define the binding of Get All Feeding Log query
+type GetAllFeedingLogRequest struct {
+	PaginationRequest
+	FeedType string    `form:"feed_type"`
+	From     time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To       time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Struct that define the binding of Feeding Summary query, biomass is in kilogram
+type FeedingSummaryRequest struct {
+	From            time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To              time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	StartingBiomass *float64  `form:"starting_biomass" binding:"required,gte=0"`
+	CurrentBiomass  *float64  `form:"current_biomass" binding:"required,gte=0"`
+}
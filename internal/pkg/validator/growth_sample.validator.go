@@ -0,0 +1,18 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Growth Sample Request.
+// TotalWeight is in gram, EstimatedCount is the estimated living population of the pond
+type CreateGrowthSampleRequest struct {
+	SampledAt      *time.Time `json:"sampled_at" form:"sampled_at"`
+	SampleCount    uint       `json:"sample_count" form:"sample_count" binding:"required,min=1"`
+	TotalWeight    float64    `json:"total_weight" form:"total_weight" binding:"required,gt=0"`
+	EstimatedCount *uint      `json:"estimated_count" form:"estimated_count"`
+}
+
+// Struct that define the binding of Get All Growth Sample query
+type GetAllGrowthSampleRequest struct {
+	PaginationRequest
+	CycleId uint `form:"cycle_id"`
+}
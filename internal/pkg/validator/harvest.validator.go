@@ -0,0 +1,28 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Harvest Request.
+// TotalWeight is in kilogram and PricePerKg is the sale price of a kilogram
+type CreateHarvestRequest struct {
+	Type        string     `json:"type" form:"type" binding:"required,oneof=partial full"`
+	HarvestedAt *time.Time `json:"harvested_at" form:"harvested_at"`
+	Quantity    uint       `json:"quantity" form:"quantity"`
+	TotalWeight float64    `json:"total_weight" form:"total_weight" binding:"required,gt=0"`
+	SizeGrade   string     `json:"size_grade" form:"size_grade" binding:"max=30"`
+	PricePerKg  float64    `json:"price_per_kg" form:"price_per_kg" binding:"gte=0"`
+}
+
+// Struct that define the binding of Get All Harvest query
+type GetAllHarvestRequest struct {
+	PaginationRequest
+	CycleId uint      `form:"cycle_id"`
+	From    time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To      time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Struct that define the binding of Farm Yield query
+type FarmYieldRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,48 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Inventory Item Request.
+// LowStockThreshold is in the unit of the item
+type CreateInventoryItemRequest struct {
+	Name              string   `json:"name" form:"name" binding:"required,max=255"`
+	Category          string   `json:"category" form:"category" binding:"required,oneof=feed lime probiotic medicine other"`
+	Unit              string   `json:"unit" form:"unit" binding:"required,max=20"`
+	LowStockThreshold *float64 `json:"low_stock_threshold" form:"low_stock_threshold" binding:"omitempty,gte=0"`
+}
+
+// Struct that define the validator/binding of Update Inventory Item Request
+type UpdateInventoryItemRequest struct {
+	Name              string   `json:"name" form:"name" binding:"max=255"`
+	Category          string   `json:"category" form:"category" binding:"omitempty,oneof=feed lime probiotic medicine other"`
+	Unit              string   `json:"unit" form:"unit" binding:"max=20"`
+	LowStockThreshold *float64 `json:"low_stock_threshold" form:"low_stock_threshold" binding:"omitempty,gte=0"`
+}
+
+// Struct that define the binding of Get All Inventory Item query
+type GetAllInventoryItemRequest struct {
+	PaginationRequest
+	Name     string `form:"name"`
+	Category string `form:"category"`
+	LowStock bool   `form:"low_stock"`
+}
+
+// Struct that define the validator/binding of Create Stock Movement Request.
+// Quantity of purchase and consumption is positive, adjustment can be negative to write off the stock
+type CreateStockMovementRequest struct {
+	Type     string     `json:"type" form:"type" binding:"required,oneof=purchase consumption adjustment"`
+	Quantity float64    `json:"quantity" form:"quantity" binding:"required"`
+	PondId   *uint      `json:"pond_id" form:"pond_id"`
+	UnitCost *float64   `json:"unit_cost" form:"unit_cost" binding:"omitempty,gte=0"`
+	Note     string     `json:"note" form:"note" binding:"max=255"`
+	MovedAt  *time.Time `json:"moved_at" form:"moved_at"`
+}
+
+// Struct that define the binding of Get All Stock Movement query
+type GetAllStockMovementRequest struct {
+	PaginationRequest
+	Type   string    `form:"type"`
+	PondId uint      `form:"pond_id"`
+	From   time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
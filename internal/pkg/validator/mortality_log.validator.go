@@ -0,0 +1,31 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Mortality Log Request
+type CreateMortalityLogRequest struct {
+	Count      uint       `json:"count" form:"count" binding:"required,min=1"`
+	Cause      string     `json:"cause" form:"cause" binding:"max=100"`
+	RecordedAt *time.Time `json:"recorded_at" form:"recorded_at"`
+}
+
+// Struct that define the binding of Get All Mortality Log query
+type GetAllMortalityLogRequest struct {
+	PaginationRequest
+	CycleId uint      `form:"cycle_id"`
+	From    time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To      time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Struct that define the binding of Survival Rate query, default to the active cycle
+type MortalitySurvivalRequest struct {
+	CycleId uint `form:"cycle_id"`
+}
+
+// Struct that define the binding of Mortality Spike query, default to the active cycle.
+// Threshold is the percent of alive stock that die in the window
+type MortalitySpikeRequest struct {
+	CycleId     uint    `form:"cycle_id"`
+	WindowHours uint    `form:"window_hours" binding:"omitempty,min=1,max=720"`
+	Threshold   float64 `form:"threshold" binding:"omitempty,gt=0,lte=100"`
+}
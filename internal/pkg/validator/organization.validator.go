@@ -0,0 +1,11 @@
+package validator
+
+// Struct that define the validator/binding of Create Organization Request
+type CreateOrganizationRequest struct {
+	Name string `json:"name" form:"name" binding:"required,min=1"`
+}
+
+// Struct that define the validator/binding of Add Member Request
+type AddMemberRequest struct {
+	UserId uint `json:"user_id" form:"user_id" binding:"required"`
+}
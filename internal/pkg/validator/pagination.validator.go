@@ -0,0 +1,26 @@
+package validator
+
+import "github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+
+// Struct that define the binding of pagination query.
+// If "cursor" is present (even empty for the first page), cursor mode is used
+type PaginationRequest struct {
+	Page   int     `form:"page" binding:"omitempty,min=1"`
+	Limit  int     `form:"limit" binding:"omitempty,min=1,max=100"`
+	Sort   string  `form:"sort"`
+	Cursor *string `form:"cursor"`
+}
+
+// Func to convert the request into Pagination
+func (request PaginationRequest) ToPagination() helpers.Pagination {
+	pagination := helpers.Pagination{
+		Page:  request.Page,
+		Limit: request.Limit,
+		Sort:  request.Sort,
+	}
+	if request.Cursor != nil {
+		pagination.CursorMode = true
+		pagination.Cursor = *request.Cursor
+	}
+	return pagination
+}
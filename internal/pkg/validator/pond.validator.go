@@ -1,14 +1,46 @@
 package validator
 
-// Struct that define the validator/binding of Create Pond Request
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+// Struct that define the validator/binding of Create Pond Request.
+// Area is in m², Depth is in m, Volume is in m³ and AeratorCapacity is in horsepower.
+// Stocked count is recorded as initial count of the cycle, so StockingCount is only checked against the density of new pond
 type CreatePondRequest struct {
-	Name   string `json:"name" form:"name" binding:"required,min=1"`
-	FarmId uint   `json:"farm_id" form:"farm_id" binding:"required"`
+	Name            string         `json:"name" form:"name" binding:"required,min=1"`
+	FarmId          uint           `json:"farm_id" form:"farm_id" binding:"required"`
+	Area            float64        `json:"area" form:"area" binding:"gte=0"`
+	Depth           float64        `json:"depth" form:"depth" binding:"gte=0"`
+	Volume          float64        `json:"volume" form:"volume" binding:"gte=0"`
+	Type            string         `json:"type" form:"type" binding:"omitempty,oneof=earthen lined tank ras"`
+	Lining          string         `json:"lining" form:"lining" binding:"max=50"`
+	AeratorCapacity float64        `json:"aerator_capacity" form:"aerator_capacity" binding:"gte=0"`
+	Status          string         `json:"status" form:"status" binding:"omitempty,oneof=active drying maintenance"`
+	StockingCount   *uint          `json:"stocking_count" form:"stocking_count" binding:"omitempty,min=1"`
+	Outline         models.Polygon `json:"outline" form:"outline"`
 }
 
-// Struct that define the validator/binding of Update Farm Request
+// Struct that define the validator/binding of Update Farm Request.
+// StockingCount replace the initial count of the active cycle of existing pond
 type UpdatePondRequest struct {
-	ID     uint   `json:"id" form:"id"`
-	Name   string `json:"name" form:"name"`
-	FarmId uint   `json:"farm_id" form:"farm_id"`
+	ID              uint           `json:"id" form:"id"`
+	Name            string         `json:"name" form:"name"`
+	FarmId          uint           `json:"farm_id" form:"farm_id"`
+	Area            float64        `json:"area" form:"area" binding:"gte=0"`
+	Depth           float64        `json:"depth" form:"depth" binding:"gte=0"`
+	Volume          float64        `json:"volume" form:"volume" binding:"gte=0"`
+	Type            string         `json:"type" form:"type" binding:"omitempty,oneof=earthen lined tank ras"`
+	Lining          string         `json:"lining" form:"lining" binding:"max=50"`
+	AeratorCapacity float64        `json:"aerator_capacity" form:"aerator_capacity" binding:"gte=0"`
+	Status          string         `json:"status" form:"status" binding:"omitempty,oneof=active drying maintenance"`
+	StockingCount   *uint          `json:"stocking_count" form:"stocking_count" binding:"omitempty,min=1"`
+	Outline         models.Polygon `json:"outline" form:"outline"`
+}
+
+// Struct that define the binding of Get All Pond query
+type GetAllPondRequest struct {
+	PaginationRequest
+	Name   string `form:"name"`
+	FarmId uint   `form:"farm_id"`
+	Type   string `form:"type" binding:"omitempty,oneof=earthen lined tank ras"`
+	Status string `form:"status" binding:"omitempty,oneof=active drying maintenance"`
 }
@@ -0,0 +1,12 @@
+package validator
+
+import "time"
+
+// Struct that define the binding of Get All Record Api query.
+// When group_by is present, the records are summed per route or status instead of paginated
+type GetAllRecordApiRequest struct {
+	PaginationRequest
+	From    time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To      time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	GroupBy string    `form:"group_by" binding:"omitempty,oneof=route status"`
+}
@@ -0,0 +1,25 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Treatment Log Request
+type CreateTreatmentLogRequest struct {
+	Product        string     `json:"product" form:"product" binding:"required,max=255"`
+	Dose           float64    `json:"dose" form:"dose" binding:"required,gt=0"`
+	DoseUnit       string     `json:"dose_unit" form:"dose_unit" binding:"required,max=20"`
+	WithdrawalDays uint       `json:"withdrawal_days" form:"withdrawal_days" binding:"max=365"`
+	Reason         string     `json:"reason" form:"reason" binding:"max=255"`
+	TreatedAt      *time.Time `json:"treated_at" form:"treated_at"`
+}
+
+// Struct that define the binding of Get All Treatment Log query
+type GetAllTreatmentLogRequest struct {
+	PaginationRequest
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// Struct that define the binding of Harvest Clearance query, default to now
+type HarvestClearanceRequest struct {
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
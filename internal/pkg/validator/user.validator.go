@@ -0,0 +1,31 @@
+package validator
+
+// Struct that define the validator/binding of Register Request
+type RegisterRequest struct {
+	Name     string `json:"name" form:"name" binding:"required,min=1"`
+	Email    string `json:"email" form:"email" binding:"required,email"`
+	Password string `json:"password" form:"password" binding:"required,min=8"`
+}
+
+// Struct that define the validator/binding of Login Request
+type LoginRequest struct {
+	Email    string `json:"email" form:"email" binding:"required,email"`
+	Password string `json:"password" form:"password" binding:"required"`
+}
+
+// Struct that define the validator/binding of Refresh Token Request
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token" binding:"required"`
+}
+
+// Struct that define the validator/binding of Logout Request.
+// If "All" is true, every session of the user is revoked
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
+	All          bool   `json:"all" form:"all"`
+}
+
+// Struct that define the validator/binding of Update Role Request
+type UpdateRoleRequest struct {
+	Role string `json:"role" form:"role" binding:"required,oneof=admin farm_manager operator viewer"`
+}
@@ -0,0 +1,28 @@
+package validator
+
+import "time"
+
+// Struct that define the validator/binding of Create Water Quality Reading Request.
+// Range of each parameter is the physically plausible range in aquaculture pond
+type CreateWaterQualityReadingRequest struct {
+	MeasuredAt      *time.Time `json:"measured_at" form:"measured_at"`
+	DissolvedOxygen *float64   `json:"dissolved_oxygen" form:"dissolved_oxygen" binding:"omitempty,gte=0,lte=30"` // mg/L
+	Ph              *float64   `json:"ph" form:"ph" binding:"omitempty,gte=0,lte=14"`
+	Temperature     *float64   `json:"temperature" form:"temperature" binding:"omitempty,gte=-5,lte=50"` // celsius
+	Salinity        *float64   `json:"salinity" form:"salinity" binding:"omitempty,gte=0,lte=70"`        // ppt
+	Ammonia         *float64   `json:"ammonia" form:"ammonia" binding:"omitempty,gte=0,lte=100"`         // mg/L
+	Nitrite         *float64   `json:"nitrite" form:"nitrite" binding:"omitempty,gte=0,lte=100"`         // mg/L
+}
+
+// Func to check whether at least one parameter is measured
+func (request CreateWaterQualityReadingRequest) HasMeasurement() bool {
+	return request.DissolvedOxygen != nil || request.Ph != nil || request.Temperature != nil ||
+		request.Salinity != nil || request.Ammonia != nil || request.Nitrite != nil
+}
+
+// Struct that define the binding of Get All Water Quality Reading query
+type GetAllWaterQualityReadingRequest struct {
+	PaginationRequest
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To   time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+}
@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+var deviceKeyHelper *deviceKeyCryptoHelper
+
+// Contract for Device Key Crypto Helper
+type DeviceKeyCryptoHelper interface {
+	GenerateDeviceKey() (string, error)
+	HashDeviceKey(key string) string
+}
+
+// Struct to implement Device Key Crypto Helper
+type deviceKeyCryptoHelper struct {
+}
+
+// Func to initialize Device Key Crypto Helper
+func GetDeviceKeyCryptoHelper() DeviceKeyCryptoHelper {
+	if deviceKeyHelper == nil {
+		deviceKeyHelper = &deviceKeyCryptoHelper{}
+	}
+	return deviceKeyHelper
+}
+
+// Generate opaque random device api key (32 bytes, url-safe)
+func (helper *deviceKeyCryptoHelper) GenerateDeviceKey() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// Hash device api key before storing it, so leaked rows can not be used
+func (helper *deviceKeyCryptoHelper) HashDeviceKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
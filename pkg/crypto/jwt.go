@@ -6,19 +6,23 @@ import (
 
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
 	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
 )
 
 var jwtHelper *jwtCryptoHelper
 
 // Contract fot JWT Crypto Helper
 type JWTCryptoHelper interface {
-	GenerateToken(UserId string) (string, error)
+	GenerateToken(UserId string, Role string, OrganizationIds []uint) (string, error)
 	ValidateToken(tokenString string) (bool, error)
+	ParseToken(tokenString string) (*jwtCustomClaim, error)
 }
 
 // Struct for jwt custom claim
 type jwtCustomClaim struct {
-	UserID string `json:"user_id"`
+	UserID          string `json:"user_id"`
+	Role            string `json:"role"`
+	OrganizationIds []uint `json:"organization_ids"`
 	jwt.StandardClaims
 }
 
@@ -34,13 +38,18 @@ func GetJWTCrypto() JWTCryptoHelper {
 	return jwtHelper
 }
 
-// Func to Generate Token with User ID as main issuer
-func (helper *jwtCryptoHelper) GenerateToken(UserID string) (string, error) {
+// Func to Generate short-lived access token with User ID as main issuer,
+// its Role and the Organization IDs (tenant) it belongs to.
+// Every token carry unique id (jti) so it can be revoked before expiry
+func (helper *jwtCryptoHelper) GenerateToken(UserID string, Role string, OrganizationIds []uint) (string, error) {
 	serverConfiguration := config.GetConfig().Server
 	claims := &jwtCustomClaim{
 		UserID,
+		Role,
+		OrganizationIds,
 		jwt.StandardClaims{
-			ExpiresAt: time.Now().Local().Add(time.Hour * time.Duration(serverConfiguration.ExpiresHour)).Unix(),
+			Id:        uuid.NewString(),
+			ExpiresAt: time.Now().Local().Add(time.Minute * time.Duration(serverConfiguration.AccessExpiresMinute)).Unix(),
 			Issuer:    serverConfiguration.Name,
 			IssuedAt:  time.Now().Unix(),
 		},
@@ -67,3 +76,22 @@ func (helper *jwtCryptoHelper) ValidateToken(tokenString string) (bool, error) {
 	}
 	return token.Valid, nil
 }
+
+// Func to parse token and return its custom claim
+func (helper *jwtCryptoHelper) ParseToken(tokenString string) (*jwtCustomClaim, error) {
+	serverConfiguration := config.GetConfig().Server
+	claims := &jwtCustomClaim{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("there was an error")
+		}
+		return []byte(serverConfiguration.Secret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, fmt.Errorf("token is not valid")
+	}
+	return claims, nil
+}
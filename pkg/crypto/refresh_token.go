@@ -0,0 +1,43 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+var refreshTokenHelper *refreshTokenCryptoHelper
+
+// Contract for Refresh Token Crypto Helper
+type RefreshTokenCryptoHelper interface {
+	GenerateRefreshToken() (string, error)
+	HashRefreshToken(token string) string
+}
+
+// Struct to implement Refresh Token Crypto Helper
+type refreshTokenCryptoHelper struct {
+}
+
+// Func to initialize Refresh Token Crypto Helper
+func GetRefreshTokenCryptoHelper() RefreshTokenCryptoHelper {
+	if refreshTokenHelper == nil {
+		refreshTokenHelper = &refreshTokenCryptoHelper{}
+	}
+	return refreshTokenHelper
+}
+
+// Generate opaque random refresh token (32 bytes, url-safe)
+func (helper *refreshTokenCryptoHelper) GenerateRefreshToken() (string, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(randomBytes), nil
+}
+
+// Hash refresh token before storing it, so leaked rows can not be used
+func (helper *refreshTokenCryptoHelper) HashRefreshToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
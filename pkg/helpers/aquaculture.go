@@ -0,0 +1,49 @@
+package helpers
+
+import "time"
+
+// Helper function to compute feed conversion ratio (FCR), the feed given
+// divided by the biomass gained. FCR is undefined when there is no biomass gain,
+// in that case false is returned
+func FeedConversionRatio(totalFeed, startingBiomass, currentBiomass float64) (float64, bool) {
+	gain := currentBiomass - startingBiomass
+	if gain <= 0 {
+		return 0, false
+	}
+	return totalFeed / gain, true
+}
+
+// Helper function to compute the days of culture from stocking until the time given
+func DaysOfCulture(stockingDate, at time.Time) float64 {
+	return at.Sub(stockingDate).Hours() / 24
+}
+
+// Helper function to compute daily growth rate (gram / day) from the initial average body weight.
+// It is undefined when no day has passed, in that case false is returned
+func DailyGrowthRate(initialAverageWeight, currentAverageWeight, days float64) (float64, bool) {
+	if days <= 0 {
+		return 0, false
+	}
+	return (currentAverageWeight - initialAverageWeight) / days, true
+}
+
+// Helper function to compute the stock that is still alive, it never goes below zero
+func RemainingCount(initialCount, deaths uint) uint {
+	if deaths >= initialCount {
+		return 0
+	}
+	return initialCount - deaths
+}
+
+// Helper function to compute survival rate (percent) of the stocked count
+func SurvivalRate(currentCount, initialCount uint) float64 {
+	if initialCount == 0 {
+		return 0
+	}
+	return float64(currentCount) / float64(initialCount) * 100
+}
+
+// Helper function to compute standing biomass (kilogram) from average body weight (gram)
+func Biomass(averageBodyWeight float64, count uint) float64 {
+	return averageBodyWeight * float64(count) / 1000
+}
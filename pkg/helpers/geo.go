@@ -0,0 +1,41 @@
+package helpers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidBoundingBox = errors.New("bbox must be \"min_longitude,min_latitude,max_longitude,max_latitude\"")
+
+// Bounding box of coordinates, in the order of GeoJSON bbox
+type BoundingBox struct {
+	MinLongitude float64
+	MinLatitude  float64
+	MaxLongitude float64
+	MaxLatitude  float64
+}
+
+// Helper function to parse "min_longitude,min_latitude,max_longitude,max_latitude" into bounding box
+func ParseBoundingBox(value string) (*BoundingBox, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 4 {
+		return nil, ErrInvalidBoundingBox
+	}
+
+	bounds := make([]float64, 0, 4)
+	for _, part := range parts {
+		bound, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, ErrInvalidBoundingBox
+		}
+		bounds = append(bounds, bound)
+	}
+
+	box := BoundingBox{MinLongitude: bounds[0], MinLatitude: bounds[1], MaxLongitude: bounds[2], MaxLatitude: bounds[3]}
+	if box.MinLongitude < -180 || box.MaxLongitude > 180 || box.MinLatitude < -90 || box.MaxLatitude > 90 ||
+		box.MinLongitude > box.MaxLongitude || box.MinLatitude > box.MaxLatitude {
+		return nil, ErrInvalidBoundingBox
+	}
+	return &box, nil
+}
@@ -1,16 +1,46 @@
 package helpers
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// Error when the sort field is not in the whitelist
+var ErrInvalidSort = errors.New("invalid sort field")
+
+// Error when the cursor can not be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Direction of cursor
+const (
+	CursorNext = "next"
+	CursorPrev = "prev"
+)
+
 // Pagination struct for query result
 // For more reference, you can access https://dev.to/rafaelgfirmino/pagination-using-gorm-scopes-3k5f
+// If CursorMode is true, keyset pagination (by id) is used instead of offset
 type Pagination struct {
 	Limit      int         `json:"limit,omitempty" query:"limit"`
 	Page       int         `json:"page,omitempty" query:"page"`
 	Sort       string      `json:"sort,omitempty" query:"sort"`
+	Cursor     string      `json:"cursor,omitempty" query:"cursor"`
+	CursorMode bool        `json:"-"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	PrevCursor string      `json:"prev_cursor,omitempty"`
 	TotalRows  int64       `json:"total_rows,omitempty"`
 	TotalPages int         `json:"total_pages,omitempty"`
 	Rows       interface{} `json:"rows"`
 }
 
+// Struct of the decoded (opaque) cursor
+type cursor struct {
+	ID        uint   `json:"id"`
+	Direction string `json:"d"`
+}
+
 // Func to get Offset for querying
 func (p *Pagination) GetOffset() int {
 	return (p.GetPage() - 1) * p.GetLimit()
@@ -35,7 +65,56 @@ func (p *Pagination) GetPage() int {
 // Func to get Sort
 func (p *Pagination) GetSort() string {
 	if p.Sort == "" {
-		p.Sort = "Id desc"
+		p.Sort = "id desc"
 	}
 	return p.Sort
 }
+
+// Func to validate Sort against the allowed fields and normalize it into "field direction".
+// Accepted format is "field", "-field" (descending), "field asc" or "field desc"
+func (p *Pagination) ValidateSort(allowedFields []string) error {
+	if p.Sort == "" {
+		return nil
+	}
+
+	field, direction := strings.TrimSpace(p.Sort), "asc"
+	if strings.HasPrefix(field, "-") {
+		field, direction = strings.TrimPrefix(field, "-"), "desc"
+	} else if splitted := strings.Fields(field); len(splitted) == 2 {
+		field, direction = splitted[0], strings.ToLower(splitted[1])
+	}
+
+	if direction != "asc" && direction != "desc" {
+		return ErrInvalidSort
+	}
+	for _, allowedField := range allowedFields {
+		if field == allowedField {
+			p.Sort = field + " " + direction
+			return nil
+		}
+	}
+	return ErrInvalidSort
+}
+
+// Func to encode id and direction into opaque cursor
+func EncodeCursor(id uint, direction string) string {
+	encoded, _ := json.Marshal(cursor{ID: id, Direction: direction})
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// Func to decode opaque cursor into id and direction
+func DecodeCursor(encoded string) (uint, string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, "", ErrInvalidCursor
+	}
+
+	var c cursor
+	if err := json.Unmarshal(decoded, &c); err != nil || c.ID == 0 {
+		return 0, "", ErrInvalidCursor
+	}
+	if c.Direction != CursorNext && c.Direction != CursorPrev {
+		return 0, "", ErrInvalidCursor
+	}
+	return c.ID, c.Direction, nil
+}
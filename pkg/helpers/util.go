@@ -39,3 +39,24 @@ func ParseUint(id string) (uint, error) {
 	}
 	return uint(parsedUint), nil
 }
+
+// Helper function to get the tenant (organization ids) of the caller
+// that is set by AuthJWT middleware
+func GetTenant(c *gin.Context) []uint {
+	organizationIds, ok := c.Get("organization_ids")
+	if !ok {
+		return []uint{}
+	}
+	ids, _ := organizationIds.([]uint)
+	return ids
+}
+
+// Helper function to check whether the id is in the list
+func ContainsUint(ids []uint, id uint) bool {
+	for _, each := range ids {
+		if each == id {
+			return true
+		}
+	}
+	return false
+}
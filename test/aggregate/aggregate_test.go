@@ -0,0 +1,103 @@
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/aggregate"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type AggregateSuite struct {
+	suite.Suite
+}
+
+func TestAggregate(t *testing.T) {
+	suite.Run(t, new(AggregateSuite))
+}
+
+var (
+	from = time.Date(2022, 2, 10, 6, 20, 0, 0, time.UTC)
+	to   = time.Date(2022, 2, 10, 9, 0, 0, 0, time.UTC)
+)
+
+// Test query is aligned to the bucket and cover until to
+func (suite *AggregateSuite) TestNewQuery_Positive() {
+	query, err := aggregate.NewQuery("do", "", "1h", from, to)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when query is valid")
+	a.Equal(models.ParameterDissolvedOxygen, query.Parameter, "do should be dissolved oxygen")
+	a.Equal(aggregate.FunctionAvg, query.Function, "default function should be avg")
+	a.Equal(time.Date(2022, 2, 10, 6, 0, 0, 0, time.UTC), query.From, "from should be aligned to the bucket start")
+	a.Len(query.Buckets(), 3, "buckets should cover from until to")
+
+	daily, err := aggregate.NewQuery("ph", aggregate.FunctionMax, "1d", from, to.Add(72*time.Hour))
+	a.NoError(err, "interval in days should be valid")
+	a.Equal(24*time.Hour, daily.Interval, "1d should be 24 hours")
+	a.Len(daily.Buckets(), 4, "daily buckets should be aligned to midnight")
+}
+
+// Test invalid query
+func (suite *AggregateSuite) TestNewQuery_Negative() {
+	a := suite.Assert()
+	for _, query := range []struct{ metric, function, interval string }{
+		{"turbidity", "avg", "1h"},
+		{"do", "sum", "1h"},
+		{"do", "avg", "hourly"},
+		{"do", "avg", "30s"},
+		{"do", "avg", "1m"},
+	} {
+		_, err := aggregate.NewQuery(query.metric, query.function, query.interval, from, to.Add(30*24*time.Hour))
+		a.ErrorIs(err, aggregate.ErrInvalidQuery, "query %v should be invalid", query)
+	}
+
+	_, err := aggregate.NewQuery("do", "avg", "1h", to, from)
+	a.ErrorIs(err, aggregate.ErrInvalidQuery, "from after to should be invalid")
+}
+
+// Test cached bucket is evicted when the cache is full, and invalidated by new measurement
+func (suite *AggregateSuite) TestCache() {
+	query, _ := aggregate.NewQuery("do", "avg", "1h", from, to)
+	starts := query.Buckets()
+	cache := aggregate.NewCache(2, time.Hour)
+	value := 5.5
+	for _, start := range starts {
+		cache.Set(1, query, aggregate.Bucket{Start: start, Value: &value, Count: 60})
+	}
+
+	a := suite.Assert()
+	a.Equal(2, cache.Len(), "cache should not hold more than its capacity")
+	_, ok := cache.Get(1, query, starts[0])
+	a.False(ok, "the least recently used bucket should be evicted")
+	bucket, ok := cache.Get(1, query, starts[1])
+	a.True(ok, "recent bucket should be cached")
+	a.Equal(int64(60), bucket.Count, "cached bucket should be returned")
+
+	_, ok = cache.Get(2, query, starts[1])
+	a.False(ok, "bucket of another pond should not be shared")
+
+	cache.Invalidate(1, starts[2].Add(10*time.Minute), starts[2].Add(20*time.Minute))
+	_, ok = cache.Get(1, query, starts[2])
+	a.False(ok, "bucket that cover the new measurement should be invalidated")
+	_, ok = cache.Get(1, query, starts[1])
+	a.True(ok, "bucket outside the new measurement should stay cached")
+}
+
+// Test cached bucket expires after the ttl, so measurement stored through another instance is seen
+func (suite *AggregateSuite) TestCache_Expired() {
+	query, _ := aggregate.NewQuery("do", "avg", "1h", from, to)
+	start := query.Buckets()[0]
+	cache := aggregate.NewCache(10, 10*time.Millisecond)
+	cache.Set(1, query, aggregate.Bucket{Start: start, Count: 60})
+
+	a := suite.Assert()
+	_, ok := cache.Get(1, query, start)
+	a.True(ok, "bucket should be cached before the ttl")
+
+	time.Sleep(20 * time.Millisecond)
+	_, ok = cache.Get(1, query, start)
+	a.False(ok, "bucket should expire after the ttl")
+	a.Equal(0, cache.Len(), "expired bucket should be removed")
+}
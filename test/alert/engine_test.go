@@ -0,0 +1,83 @@
+package alert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/alert"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type AlertEngineSuite struct {
+	suite.Suite
+}
+
+func TestAlertEngine(t *testing.T) {
+	suite.Run(t, new(AlertEngineSuite))
+}
+
+// Test severity of value against default dissolved oxygen threshold (lower bound only)
+func (suite *AlertEngineSuite) TestSeverity_DissolvedOxygen() {
+	threshold := alert.DefaultThresholds[models.ParameterDissolvedOxygen]
+	a := suite.Assert()
+
+	a.Equal("", alert.Severity(threshold, 6), "value in safe range should not raise alert")
+	a.Equal(models.AlertSeverityWarning, alert.Severity(threshold, 3.5), "value below min should raise warning")
+	a.Equal(models.AlertSeverityCritical, alert.Severity(threshold, 2), "value below critical min should raise critical")
+}
+
+// Test severity of value against default ph threshold (both bounds)
+func (suite *AlertEngineSuite) TestSeverity_Ph() {
+	threshold := alert.DefaultThresholds[models.ParameterPh]
+	a := suite.Assert()
+
+	a.Equal("", alert.Severity(threshold, 7.5), "value in safe range should not raise alert")
+	a.Equal(models.AlertSeverityWarning, alert.Severity(threshold, 9.2), "value above max should raise warning")
+	a.Equal(models.AlertSeverityCritical, alert.Severity(threshold, 10), "value above critical max should raise critical")
+}
+
+// Test severity of value against threshold without any bound
+func (suite *AlertEngineSuite) TestSeverity_NoBound() {
+	a := suite.Assert()
+	a.Equal("", alert.Severity(models.PondThreshold{}, 1000), "threshold without bound should never raise alert")
+}
+
+// Test only the latest measurement of every pond in the batch is evaluated
+func (suite *AlertEngineSuite) TestLatestMeasurements() {
+	at := time.Date(2022, 2, 10, 6, 0, 0, 0, time.UTC)
+	measurements := []models.Measurement{
+		{ID: 1, PondId: 2, MeasuredAt: at.Add(time.Minute)},
+		{ID: 2, PondId: 1, MeasuredAt: at},
+		{ID: 3, PondId: 2, MeasuredAt: at.Add(2 * time.Minute)},
+		{ID: 4, PondId: 2, MeasuredAt: at},
+	}
+
+	latestMeasurements := alert.LatestMeasurements(measurements)
+	a := suite.Assert()
+	a.Len(latestMeasurements, 2, "there should be one measurement per pond")
+	a.Equal(uint(2), latestMeasurements[0].ID, "the only measurement of pond should be the latest")
+	a.Equal(uint(3), latestMeasurements[1].ID, "the measurement measured last should be the latest")
+}
+
+// Test alert merged from measurement take the source and bound of the latest one
+func (suite *AlertEngineSuite) TestMerge() {
+	oldMin, newMin := 4.0, 5.0
+	measurementId := uint(7)
+	existedAlert := models.Alert{
+		ReadingId:   3,
+		Min:         &oldMin,
+		Severity:    models.AlertSeverityCritical,
+		Occurrences: 1,
+	}
+	threshold := models.PondThreshold{Min: &newMin}
+
+	mergedAlert := alert.Merge(existedAlert, models.Alert{MeasurementId: &measurementId}, threshold, 4.5, models.AlertSeverityWarning)
+	a := suite.Assert()
+	a.Equal(uint(0), mergedAlert.ReadingId, "reading id should be 0 when merged from measurement")
+	a.Equal(&measurementId, mergedAlert.MeasurementId, "measurement id should be the latest source")
+	a.Equal(&newMin, mergedAlert.Min, "bound should be refreshed from the current threshold")
+	a.Nil(mergedAlert.Max, "bound should be refreshed from the current threshold")
+	a.Equal(models.AlertSeverityCritical, mergedAlert.Severity, "severity should not be lowered")
+	a.Equal(uint(2), mergedAlert.Occurrences, "occurrences should be counted")
+}
@@ -7,6 +7,9 @@ import (
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
 )
 
 // Helper for database params
@@ -24,9 +27,27 @@ var (
 
 	// Models that involved
 	Models = []interface{}{
+		"user_organizations",
+		&models.Organization{},
 		&models.Farm{},
 		&models.Pond{},
+		&models.WaterQualityReading{},
+		&models.PondThreshold{},
+		&models.Alert{},
+		&models.Cycle{},
+		&models.FeedingLog{},
+		&models.GrowthSample{},
+		&models.MortalityLog{},
+		&models.Harvest{},
+		&models.Measurement{},
+		&models.Device{},
+		&models.StockMovement{},
+		&models.TreatmentLog{},
+		&models.InventoryItem{},
 		&models.RecordApi{},
+		&models.RefreshToken{},
+		&models.RevokedToken{},
+		&models.User{},
 	}
 )
 
@@ -47,3 +68,29 @@ func TearDownHelper() {
 		db.GetDB().Migrator().DropTable(model)
 	}
 }
+
+// Helper to generate "Authorization" header value for protected routes
+func GenerateBearerToken(userId string, role string, organizationIds ...uint) string {
+	token, _ := crypto.GetJWTCrypto().GenerateToken(userId, role, organizationIds)
+	return "Bearer " + token
+}
+
+// Helper to insert organizations, farm must belong to one of them
+func InsertOrganizations() {
+	organizationRepo := repository.GetOrganizationRepository()
+	for _, organization := range fixtures.Organizations {
+		organizationRepo.Create(organization)
+	}
+}
+
+// Helper to insert farms and their ponds, organizations must be inserted first
+func InsertFarmsAndPonds() {
+	farmRepo := repository.GetFarmRepository()
+	for _, farm := range fixtures.Farms {
+		farmRepo.Create(farm)
+	}
+	pondRepo := repository.GetPondRepository()
+	for _, pond := range fixtures.Ponds {
+		pondRepo.Create(pond)
+	}
+}
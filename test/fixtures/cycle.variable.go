@@ -0,0 +1,34 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var Cycles []models.Cycle = []models.Cycle{
+	{
+		PondId:               1,
+		Species:              "Litopenaeus vannamei",
+		StockingDate:         time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC),
+		InitialCount:         100000,
+		InitialAverageWeight: 0.01,
+		Status:               models.CycleStatusActive,
+	},
+	{
+		PondId:               1,
+		Species:              "Litopenaeus vannamei",
+		StockingDate:         time.Date(2022, 6, 10, 0, 0, 0, 0, time.UTC),
+		InitialCount:         120000,
+		InitialAverageWeight: 0.01,
+		Status:               models.CycleStatusPlanned,
+	},
+	{
+		PondId:               2,
+		Species:              "Oreochromis niloticus",
+		StockingDate:         time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC),
+		InitialCount:         5000,
+		InitialAverageWeight: 5,
+		Status:               models.CycleStatusHarvested,
+	},
+}
@@ -0,0 +1,39 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Devices of pond 1, api key of each device is its name
+var Devices []models.Device = []models.Device{
+	{
+		PondId:     1,
+		Name:       "probe-1",
+		ApiKeyHash: "52aaae2ec0378ec1adf9e9cd95d7dd003fb49cb9a97cc77cfeb99ef45d0b60df",
+	},
+	{
+		PondId:     1,
+		Name:       "probe-2",
+		ApiKeyHash: "ee420d7cc1d2d38d5b0071f6347914ffeafb49ce125899b71a1a25d5726ad502",
+	},
+}
+
+// Measurements sent by the first device
+var Measurements []models.Measurement = []models.Measurement{
+	{
+		PondId:          1,
+		DeviceId:        1,
+		MeasuredAt:      time.Date(2022, 2, 10, 6, 0, 0, 0, time.UTC),
+		DissolvedOxygen: readingValue(5.5),
+		Temperature:     readingValue(28.5),
+	},
+	{
+		PondId:          1,
+		DeviceId:        1,
+		MeasuredAt:      time.Date(2022, 2, 10, 6, 1, 0, 0, time.UTC),
+		DissolvedOxygen: readingValue(5.4),
+		Temperature:     readingValue(28.6),
+	},
+}
@@ -2,22 +2,30 @@ package fixtures
 
 import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 
+var coordinate = func(value float64) *float64 { return &value }
+
 var Farms []models.Farm = []models.Farm{
 	{
-		Name:  "Farm 1",
-		Ponds: []models.Pond{},
+		Name:           "Farm 1",
+		Latitude:       coordinate(-7.25),
+		Longitude:      coordinate(112.75),
+		OrganizationId: 1,
+		Ponds:          []models.Pond{},
 	},
 	{
-		Name:  "Random Farm",
-		Ponds: []models.Pond{},
+		Name:           "Random Farm",
+		OrganizationId: 1,
+		Ponds:          []models.Pond{},
 	},
 	{
-		Name:  "Farm 2",
-		Ponds: []models.Pond{},
+		Name:           "Farm 2",
+		OrganizationId: 1,
+		Ponds:          []models.Pond{},
 	},
 }
 
 var WillBeFarm models.Farm = models.Farm{
-	Name:  "Random Farm",
-	Ponds: []models.Pond{},
+	Name:           "Random Farm",
+	OrganizationId: 1,
+	Ponds:          []models.Pond{},
 }
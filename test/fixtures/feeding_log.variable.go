@@ -0,0 +1,34 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var FeedingLogs []models.FeedingLog = []models.FeedingLog{
+	{
+		PondId:   1,
+		FeedType: "starter",
+		Quantity: 2.5,
+		FedAt:    time.Date(2022, 3, 1, 7, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:   1,
+		FeedType: "starter",
+		Quantity: 3,
+		FedAt:    time.Date(2022, 3, 1, 17, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:   1,
+		FeedType: "grower",
+		Quantity: 4,
+		FedAt:    time.Date(2022, 3, 2, 7, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:   2,
+		FeedType: "grower",
+		Quantity: 10,
+		FedAt:    time.Date(2022, 3, 1, 7, 0, 0, 0, time.UTC),
+	},
+}
@@ -0,0 +1,28 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var estimatedCount = func(count uint) *uint { return &count }
+
+// Samples of the active cycle (first cycle) of pond 1
+var GrowthSamples []models.GrowthSample = []models.GrowthSample{
+	{
+		PondId:      1,
+		CycleId:     1,
+		SampledAt:   time.Date(2022, 2, 10, 0, 0, 0, 0, time.UTC),
+		SampleCount: 50,
+		TotalWeight: 100,
+	},
+	{
+		PondId:         1,
+		CycleId:        1,
+		SampledAt:      time.Date(2022, 3, 12, 0, 0, 0, 0, time.UTC),
+		SampleCount:    40,
+		TotalWeight:    400,
+		EstimatedCount: estimatedCount(85000),
+	},
+}
@@ -0,0 +1,31 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Harvests of the active cycle (first cycle) of pond 1, the full harvest end the cycle
+var Harvests []models.Harvest = []models.Harvest{
+	{
+		PondId:      1,
+		CycleId:     1,
+		Type:        models.HarvestTypePartial,
+		HarvestedAt: time.Date(2022, 3, 20, 0, 0, 0, 0, time.UTC),
+		Quantity:    20000,
+		TotalWeight: 300,
+		SizeGrade:   "size 70",
+		PricePerKg:  4.5,
+	},
+	{
+		PondId:      1,
+		CycleId:     1,
+		Type:        models.HarvestTypeFull,
+		HarvestedAt: time.Date(2022, 4, 20, 0, 0, 0, 0, time.UTC),
+		Quantity:    60000,
+		TotalWeight: 1200,
+		SizeGrade:   "size 50",
+		PricePerKg:  5.5,
+	},
+}
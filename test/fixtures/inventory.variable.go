@@ -0,0 +1,45 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var lowStockThreshold = func(value float64) *float64 { return &value }
+
+var consumedBy = func(pondId uint) *uint { return &pondId }
+
+// Items of farm 1, only the feed has low stock threshold
+var InventoryItems []models.InventoryItem = []models.InventoryItem{
+	{
+		FarmId:            1,
+		Name:              "Starter Feed 30%",
+		Category:          models.InventoryCategoryFeed,
+		Unit:              "kg",
+		LowStockThreshold: lowStockThreshold(100),
+	},
+	{
+		FarmId:   1,
+		Name:     "Dolomite Lime",
+		Category: models.InventoryCategoryLime,
+		Unit:     "kg",
+	},
+}
+
+// Movements of the feed, 50 kg is left on hand
+var StockMovements []models.StockMovement = []models.StockMovement{
+	{
+		ItemId:   1,
+		Type:     models.StockMovementPurchase,
+		Quantity: 500,
+		MovedAt:  time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		ItemId:   1,
+		Type:     models.StockMovementConsumption,
+		Quantity: -450,
+		PondId:   consumedBy(1),
+		MovedAt:  time.Date(2022, 2, 20, 0, 0, 0, 0, time.UTC),
+	},
+}
@@ -0,0 +1,32 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Dead stock of the active cycle (first cycle) of pond 1
+var MortalityLogs []models.MortalityLog = []models.MortalityLog{
+	{
+		PondId:     1,
+		CycleId:    1,
+		Count:      300,
+		Cause:      "low oxygen",
+		RecordedAt: time.Date(2022, 2, 1, 5, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:     1,
+		CycleId:    1,
+		Count:      200,
+		Cause:      "low oxygen",
+		RecordedAt: time.Date(2022, 2, 1, 9, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:     1,
+		CycleId:    1,
+		Count:      100,
+		Cause:      "disease",
+		RecordedAt: time.Date(2022, 2, 20, 7, 0, 0, 0, time.UTC),
+	},
+}
@@ -0,0 +1,12 @@
+package fixtures
+
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+var Organizations []models.Organization = []models.Organization{
+	{
+		Name: "Organization 1",
+	},
+	{
+		Name: "Organization 2",
+	},
+}
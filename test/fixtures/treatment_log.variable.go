@@ -0,0 +1,36 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+// Treatments of pond 1, the antibiotic keeps the pond withdrawing until 22 March 2022
+var TreatmentLogs []models.TreatmentLog = []models.TreatmentLog{
+	{
+		PondId:         1,
+		Product:        "Oxytetracycline",
+		Dose:           2.5,
+		DoseUnit:       "g/kg feed",
+		WithdrawalDays: 21,
+		Reason:         "vibriosis",
+		TreatedAt:      time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:         1,
+		Product:        "Formalin",
+		Dose:           25,
+		DoseUnit:       "ppm",
+		WithdrawalDays: 7,
+		Reason:         "parasite",
+		TreatedAt:      time.Date(2022, 3, 5, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		PondId:    1,
+		Product:   "Bacillus Probiotic",
+		Dose:      1,
+		DoseUnit:  "ppm",
+		TreatedAt: time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC),
+	},
+}
@@ -0,0 +1,22 @@
+package fixtures
+
+import "github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+
+var Users []models.User = []models.User{
+	{
+		Name:     "User 1",
+		Email:    "user1@example.com",
+		Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z1ZBC6Vsz0hHZ0ZgKCUQsS7e",
+	},
+	{
+		Name:     "User 2",
+		Email:    "user2@example.com",
+		Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z1ZBC6Vsz0hHZ0ZgKCUQsS7e",
+	},
+}
+
+var WillBeUser models.User = models.User{
+	Name:     "Random User",
+	Email:    "random@example.com",
+	Password: "$2a$10$7EqJtq98hPqEX7fNZaFWoOhi5BWX4Z1ZBC6Vsz0hHZ0ZgKCUQsS7e",
+}
@@ -0,0 +1,31 @@
+package fixtures
+
+import (
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+)
+
+var readingValue = func(value float64) *float64 { return &value }
+
+var WaterQualityReadings []models.WaterQualityReading = []models.WaterQualityReading{
+	{
+		PondId:          1,
+		MeasuredAt:      time.Date(2022, 3, 1, 6, 0, 0, 0, time.UTC),
+		DissolvedOxygen: readingValue(5.2),
+		Ph:              readingValue(7.8),
+		Temperature:     readingValue(28.5),
+	},
+	{
+		PondId:          1,
+		MeasuredAt:      time.Date(2022, 3, 1, 18, 0, 0, 0, time.UTC),
+		DissolvedOxygen: readingValue(3.1),
+		Ammonia:         readingValue(0.4),
+	},
+	{
+		PondId:      2,
+		MeasuredAt:  time.Date(2022, 3, 2, 6, 0, 0, 0, time.UTC),
+		Salinity:    readingValue(15),
+		Temperature: readingValue(29),
+	},
+}
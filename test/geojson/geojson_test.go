@@ -0,0 +1,54 @@
+package geojson
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/geojson"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type GeoJsonSuite struct {
+	suite.Suite
+}
+
+func TestGeoJson(t *testing.T) {
+	suite.Run(t, new(GeoJsonSuite))
+}
+
+// Test farm location, boundary and pond outline become features
+func (suite *GeoJsonSuite) TestFarmFeatureCollection() {
+	latitude, longitude := -7.25, 112.75
+	farm := models.Farm{
+		Model:     gorm.Model{ID: 1},
+		Name:      "Farm 1",
+		Latitude:  &latitude,
+		Longitude: &longitude,
+		Boundary:  models.Polygon{{{112.7, -7.2}, {112.8, -7.2}, {112.8, -7.3}, {112.7, -7.2}}},
+		Ponds: []models.Pond{
+			{Model: gorm.Model{ID: 2}, Name: "Pond 1", Outline: models.Polygon{{{112.74, -7.24}, {112.75, -7.24}, {112.75, -7.25}, {112.74, -7.24}}}},
+			{Model: gorm.Model{ID: 3}, Name: "Pond 2"},
+		},
+	}
+	collection := geojson.FarmFeatureCollection(farm)
+
+	a := suite.Assert()
+	a.Equal(geojson.TypeFeatureCollection, collection.Type, "type should be FeatureCollection")
+	a.Len(collection.Features, 4, "farm location, farm boundary and every pond should be feature")
+	a.Equal([]float64{longitude, latitude}, collection.Features[0].Geometry.Coordinates, "point should be [longitude, latitude]")
+	a.Equal(geojson.TypePolygon, collection.Features[1].Geometry.Type, "farm boundary should be polygon")
+	a.Equal("pond-2", collection.Features[2].Id, "feature id should contain its kind")
+	a.Nil(collection.Features[3].Geometry, "pond without outline should have null geometry")
+}
+
+// Test farm without location only contains its ponds and serialize null geometry
+func (suite *GeoJsonSuite) TestFarmFeatureCollection_NoLocation() {
+	farm := models.Farm{Model: gorm.Model{ID: 1}, Ponds: []models.Pond{{Model: gorm.Model{ID: 2}}}}
+	body, err := json.Marshal(geojson.FarmFeatureCollection(farm))
+
+	a := suite.Assert()
+	a.NoError(err, "feature collection should be serializable")
+	a.Contains(string(body), `"geometry":null`, "unknown geometry should be null")
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/dto"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestAuthHandler(t *testing.T) {
+	suite.Run(t, new(AuthHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *AuthHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+
+	// Initialize Router for testing
+	suite.Router = v1.Setup()
+}
+
+// Function to Register then Login with the same credential
+func (suite *AuthHandlerSuite) TestRegisterAndLogin_Positive() {
+	a := suite.Assert()
+
+	registerBody, _ := json.Marshal(validator.RegisterRequest{
+		Name:     "auth user",
+		Email:    "auth@example.com",
+		Password: "password123",
+	})
+	req, w := authRequest(suite.Router, "/api/v1/auth/register", bytes.NewBuffer(registerBody))
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request status code error")
+
+	loginBody, _ := json.Marshal(validator.LoginRequest{
+		Email:    "auth@example.com",
+		Password: "password123",
+	})
+	req, w = authRequest(suite.Router, "/api/v1/auth/login", bytes.NewBuffer(loginBody))
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request status code error")
+}
+
+// Function to Refresh token, the old refresh token must not be reusable
+func (suite *AuthHandlerSuite) TestRefresh_Rotation() {
+	a := suite.Assert()
+	loginDto := registerAndLogin(suite.Router, "refresh@example.com")
+
+	refreshBody, _ := json.Marshal(validator.RefreshTokenRequest{RefreshToken: loginDto.RefreshToken})
+	_, w := authRequest(suite.Router, "/api/v1/auth/refresh", bytes.NewBuffer(refreshBody))
+	a.Equal(http.StatusOK, w.Code, "HTTP request status code error")
+
+	// Reusing the rotated token
+	refreshBody, _ = json.Marshal(validator.RefreshTokenRequest{RefreshToken: loginDto.RefreshToken})
+	_, w = authRequest(suite.Router, "/api/v1/auth/refresh", bytes.NewBuffer(refreshBody))
+	a.Equal(http.StatusUnauthorized, w.Code, "HTTP request status code error")
+}
+
+// Function to Logout, the access token must be rejected afterward
+func (suite *AuthHandlerSuite) TestLogout_RevokeAccessToken() {
+	a := suite.Assert()
+	loginDto := registerAndLogin(suite.Router, "logout@example.com")
+
+	logoutBody, _ := json.Marshal(validator.LogoutRequest{RefreshToken: loginDto.RefreshToken})
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/logout", bytes.NewBuffer(logoutBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+loginDto.Token)
+	w := httptest.NewRecorder()
+	suite.Router.ServeHTTP(w, req)
+	a.Equal(http.StatusNoContent, w.Code, "HTTP request status code error")
+
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/farm", nil)
+	req.Header.Set("Authorization", "Bearer "+loginDto.Token)
+	w = httptest.NewRecorder()
+	suite.Router.ServeHTTP(w, req)
+	a.Equal(http.StatusUnauthorized, w.Code, "HTTP request status code error")
+}
+
+// Function to Update Role of user, the refresh token of the user must be revoked
+func (suite *AuthHandlerSuite) TestUpdateRole_RevokeRefreshToken() {
+	a := suite.Assert()
+	loginDto := registerAndLogin(suite.Router, "promoted@example.com")
+
+	roleBody, _ := json.Marshal(validator.UpdateRoleRequest{Role: models.RoleFarmManager})
+	url := fmt.Sprintf("/api/v1/users/%d/role", loginDto.User.ID)
+	req, _ := http.NewRequest(http.MethodPut, url, bytes.NewBuffer(roleBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
+	w := httptest.NewRecorder()
+	suite.Router.ServeHTTP(w, req)
+	a.Equal(http.StatusOK, w.Code, "HTTP request status code error")
+
+	refreshBody, _ := json.Marshal(validator.RefreshTokenRequest{RefreshToken: loginDto.RefreshToken})
+	_, w = authRequest(suite.Router, "/api/v1/auth/refresh", bytes.NewBuffer(refreshBody))
+	a.Equal(http.StatusUnauthorized, w.Code, "HTTP request status code error")
+}
+
+// Function to Login with wrong password
+func (suite *AuthHandlerSuite) TestLogin_Negative() {
+	a := suite.Assert()
+
+	loginBody, _ := json.Marshal(validator.LoginRequest{
+		Email:    "nobody@example.com",
+		Password: "wrong-password",
+	})
+	req, w := authRequest(suite.Router, "/api/v1/auth/login", bytes.NewBuffer(loginBody))
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusUnauthorized, w.Code, "HTTP request status code error")
+}
+
+// Function to access protected route without token
+func (suite *AuthHandlerSuite) TestProtectedRoute_Negative() {
+	a := suite.Assert()
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/farm", nil)
+	if err != nil {
+		a.Error(err)
+	}
+	w := httptest.NewRecorder()
+	suite.Router.ServeHTTP(w, req)
+	a.Equal(http.StatusBadRequest, w.Code, "HTTP request status code error")
+}
+
+// Helper function for auth request
+func authRequest(r *gin.Engine, url string, body *bytes.Buffer) (*http.Request, *httptest.ResponseRecorder) {
+	req, err := http.NewRequest(http.MethodPost, url, body)
+	if err != nil {
+		panic(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return req, w
+}
+
+// Helper function to register and login new user
+func registerAndLogin(r *gin.Engine, email string) dto.LoginResponseDto {
+	registerBody, _ := json.Marshal(validator.RegisterRequest{
+		Name:     "auth user",
+		Email:    email,
+		Password: "password123",
+	})
+	authRequest(r, "/api/v1/auth/register", bytes.NewBuffer(registerBody))
+
+	loginBody, _ := json.Marshal(validator.LoginRequest{
+		Email:    email,
+		Password: "password123",
+	})
+	_, w := authRequest(r, "/api/v1/auth/login", bytes.NewBuffer(loginBody))
+
+	actual := struct {
+		Data dto.LoginResponseDto `json:"data"`
+	}{}
+	json.Unmarshal(w.Body.Bytes(), &actual)
+	return actual.Data
+}
@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type CycleHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestCycleHandler(t *testing.T) {
+	suite.Run(t, new(CycleHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *CycleHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data, first cycle is the active cycle of pond 1
+	cycleRepo := repository.GetCycleRepository()
+	for _, cycle := range fixtures.Cycles {
+		cycleRepo.Create(cycle)
+	}
+
+	// Initialize Router for testing
+	suite.Router = v1.Setup()
+}
+
+// Function to change status to harvested but rejected, cycle only become harvested by its full harvest
+func (suite *CycleHandlerSuite) TestChangeStatus_Harvested() {
+	a := suite.Assert()
+
+	requestBody, err := json.Marshal(validator.ChangeCycleStatusRequest{Status: models.CycleStatusHarvested})
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := tenantRequest(suite.Router, http.MethodPut, "/api/v1/pond/1/cycles/1/status", requestBody, 1)
+	a.Equal(http.MethodPut, req.Method, "HTTP request method error")
+	a.Equal(http.StatusBadRequest, w.Code, "HTTP request code error")
+
+	activeCycle, err := repository.GetCycleRepository().GetActiveByPond(1)
+	a.NoError(err, "pond should still have active cycle")
+	a.Equal(uint(1), activeCycle.ID, "cycle should stay active")
+}
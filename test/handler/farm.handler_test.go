@@ -13,6 +13,7 @@ import (
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
 	"github.com/adiatma85/golang-rest-template-api/pkg/response"
 	"github.com/adiatma85/golang-rest-template-api/test"
 	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
@@ -36,6 +37,7 @@ func (suite *FarmHandlerSuite) SetupSuite() {
 	// Initialize Configuration
 	test.SetupInitialize("../../.env")
 	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
 
 	// Initialize Router for testing
 	suite.Router = v1.Setup()
@@ -80,6 +82,35 @@ func (suite *FarmHandlerSuite) TestGetAllFarm_Negative() {
 	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
 }
 
+// Function to Get All with pagination query
+func (suite *FarmHandlerSuite) TestGetAllFarm_Pagination() {
+	insertFarm()
+	insertFarm()
+	a := suite.Assert()
+
+	req, w := getAllFarmWithQueryRequest(suite.Router, "?page=1&limit=1&sort=-name&name=random")
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request code error")
+
+	actual := struct {
+		Data helpers.Pagination `json:"data"`
+	}{}
+	if err := json.Unmarshal(w.Body.Bytes(), &actual); err != nil {
+		a.Error(err)
+	}
+	a.Equal(1, actual.Data.Limit, "limit is different than supposed to be")
+	a.Equal("name desc", actual.Data.Sort, "sort is different than supposed to be")
+	a.Len(actual.Data.Rows, 1, "rows should be limited")
+}
+
+// Function to Get All with sort field that is not allowed
+func (suite *FarmHandlerSuite) TestGetAllFarm_InvalidSort() {
+	a := suite.Assert()
+	req, w := getAllFarmWithQueryRequest(suite.Router, "?sort=password")
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusBadRequest, w.Code, "HTTP request code error")
+}
+
 // Function to Get By Id but return success because there is exist record
 func (suite *FarmHandlerSuite) TestGetById_Positive() {
 	farm, err := insertFarm()
@@ -179,6 +210,26 @@ func (suite *FarmHandlerSuite) TestDeleteById_Negative() {
 	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
 }
 
+// Function to Delete By Id but return forbidden because of viewer role
+func (suite *FarmHandlerSuite) TestDeleteById_Forbidden() {
+	farm, err := insertFarm()
+	a := suite.Assert()
+
+	a.NotNil(farm, "fail to insert resource")
+	a.NoError(err, "fail to insert resource")
+
+	url := fmt.Sprintf("/api/v1/farm/%d", farm.ID)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleViewer, 1))
+	w := httptest.NewRecorder()
+	suite.Router.ServeHTTP(w, req)
+	a.Equal(http.StatusForbidden, w.Code, "HTTP request code error")
+}
+
 // Helper function to createFarm
 func createFarm(r *gin.Engine, body *bytes.Buffer) (*http.Request, *httptest.ResponseRecorder) {
 	req, err := http.NewRequest(http.MethodPost, "/api/v1/farm", body)
@@ -187,6 +238,7 @@ func createFarm(r *gin.Engine, body *bytes.Buffer) (*http.Request, *httptest.Res
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -194,12 +246,18 @@ func createFarm(r *gin.Engine, body *bytes.Buffer) (*http.Request, *httptest.Res
 
 // Helper function getAllFarm
 func getAllFarmRequest(r *gin.Engine) (*http.Request, *httptest.ResponseRecorder) {
-	req, err := http.NewRequest(http.MethodGet, "/api/v1/farm", nil)
+	return getAllFarmWithQueryRequest(r, "")
+}
+
+// Helper function getAllFarm with query string
+func getAllFarmWithQueryRequest(r *gin.Engine, query string) (*http.Request, *httptest.ResponseRecorder) {
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/farm"+query, nil)
 	if err != nil {
 		panic(err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -214,6 +272,7 @@ func getFarmByIdRequest(r *gin.Engine, farmId uint) (*http.Request, *httptest.Re
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -227,6 +286,7 @@ func updateFarm(r *gin.Engine, body *bytes.Buffer) (*http.Request, *httptest.Res
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -241,6 +301,7 @@ func deleteFarmByIdRequest(r *gin.Engine, farmId uint) (*http.Request, *httptest
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
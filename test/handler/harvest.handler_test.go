@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type HarvestHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestHarvestHandler(t *testing.T) {
+	suite.Run(t, new(HarvestHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *HarvestHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	test.InsertFarmsAndPonds()
+
+	// Initialize Router for testing
+	suite.Router = v1.Setup()
+}
+
+// Function to Get All Harvest of pond of the tenant
+func (suite *HarvestHandlerSuite) TestGetAllHarvest_Positive() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/pond/1/harvests", nil, 1)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request code error")
+}
+
+// Function to Get All Harvest but return not found because the pond belong to other organization
+func (suite *HarvestHandlerSuite) TestGetAllHarvest_CrossTenant() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/pond/1/harvests", nil, 2)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Function to Create Harvest but return not found because the pond belong to other organization
+func (suite *HarvestHandlerSuite) TestCreateHarvest_CrossTenant() {
+	a := suite.Assert()
+
+	newBody := validator.CreateHarvestRequest{
+		Type:        models.HarvestTypePartial,
+		Quantity:    100,
+		TotalWeight: 2.5,
+	}
+
+	requestBody, err := json.Marshal(newBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := tenantRequest(suite.Router, http.MethodPost, "/api/v1/pond/1/harvests", requestBody, 2)
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Function to Create Harvest but rejected because the quantity exceed the remaining stock
+func (suite *HarvestHandlerSuite) TestCreateHarvest_ExceedRemaining() {
+	a := suite.Assert()
+	pond, err := repository.GetPondRepository().Create(models.Pond{Name: "small stock", FarmId: 1})
+	a.NoError(err, "fail to insert resource")
+	_, err = repository.GetCycleRepository().Create(models.Cycle{
+		PondId:       pond.ID,
+		Species:      "Litopenaeus vannamei",
+		StockingDate: time.Now().Add(-30 * 24 * time.Hour),
+		InitialCount: 100,
+		Status:       models.CycleStatusActive,
+	})
+	a.NoError(err, "fail to insert resource")
+
+	newBody := validator.CreateHarvestRequest{
+		Type:        models.HarvestTypePartial,
+		Quantity:    101,
+		TotalWeight: 2.5,
+	}
+
+	requestBody, err := json.Marshal(newBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	url := fmt.Sprintf("/api/v1/pond/%d/harvests", pond.ID)
+	req, w := tenantRequest(suite.Router, http.MethodPost, url, requestBody, 1)
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusBadRequest, w.Code, "HTTP request code error")
+}
+
+// Helper function to request route of pond or farm as admin of the organization
+func tenantRequest(r *gin.Engine, method string, url string, body []byte, organizationId uint) (*http.Request, *httptest.ResponseRecorder) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, organizationId))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return req, w
+}
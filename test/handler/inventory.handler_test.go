@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type InventoryHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestInventoryHandler(t *testing.T) {
+	suite.Run(t, new(InventoryHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *InventoryHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data
+	itemRepo := repository.GetInventoryItemRepository()
+	for _, item := range fixtures.InventoryItems {
+		itemRepo.Create(item)
+	}
+
+	// Initialize Router for testing
+	suite.Router = v1.Setup()
+}
+
+// Function to Get All Item of farm of the tenant
+func (suite *InventoryHandlerSuite) TestGetAllItem_Positive() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/farm/1/inventory", nil, 1)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request code error")
+}
+
+// Function to Get All Item but return not found because the farm belong to other organization
+func (suite *InventoryHandlerSuite) TestGetAllItem_CrossTenant() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/farm/1/inventory", nil, 2)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Function to Get Item By Id but return not found because the farm belong to other organization
+func (suite *InventoryHandlerSuite) TestGetItemById_CrossTenant() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/farm/1/inventory/1", nil, 2)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Function to Create Item but return not found because the farm belong to other organization
+func (suite *InventoryHandlerSuite) TestCreateItem_CrossTenant() {
+	a := suite.Assert()
+
+	newBody := validator.CreateInventoryItemRequest{
+		Name:     "Grower Feed 28%",
+		Category: models.InventoryCategoryFeed,
+		Unit:     "kg",
+	}
+
+	requestBody, err := json.Marshal(newBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := tenantRequest(suite.Router, http.MethodPost, "/api/v1/farm/1/inventory", requestBody, 2)
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
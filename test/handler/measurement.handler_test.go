@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/api/middleware"
+	"github.com/adiatma85/golang-rest-template-api/internal/api/router/ingest"
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type MeasurementHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestMeasurementHandler(t *testing.T) {
+	suite.Run(t, new(MeasurementHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *MeasurementHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data, api key of each device is its name
+	deviceRepo := repository.GetDeviceRepository()
+	for _, device := range fixtures.Devices {
+		deviceRepo.Create(device)
+	}
+
+	// Initialize Router for testing, ingest router is mounted beside v1 router
+	suite.Router = v1.Setup()
+	ingest.Setup(suite.Router)
+}
+
+// Function to Ingest readings of the pond of the device
+func (suite *MeasurementHandlerSuite) TestIngest_Positive() {
+	a := suite.Assert()
+	temperature := 28.4
+
+	newBody := validator.IngestRequest{
+		Readings: []validator.IngestReadingRequest{
+			{MeasuredAt: time.Now().UTC().Truncate(time.Second), Temperature: &temperature},
+		},
+	}
+
+	requestBody, err := json.Marshal(newBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := ingestRequest(suite.Router, fixtures.Devices[0].Name, requestBody)
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request code error")
+}
+
+// Function to Ingest readings but return unauthorized because the device key is unknown
+func (suite *MeasurementHandlerSuite) TestIngest_InvalidKey() {
+	a := suite.Assert()
+	req, w := ingestRequest(suite.Router, "unknown-probe", []byte(`{"readings":[]}`))
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusUnauthorized, w.Code, "HTTP request code error")
+}
+
+// Function to Get All Measurement of pond of the tenant
+func (suite *MeasurementHandlerSuite) TestGetAllMeasurement_Positive() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/pond/1/measurements", nil, 1)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request code error")
+}
+
+// Function to Get All Measurement but return not found because the pond belong to other organization
+func (suite *MeasurementHandlerSuite) TestGetAllMeasurement_CrossTenant() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/pond/1/measurements", nil, 2)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Helper function to ingest readings as the device with the api key
+func ingestRequest(r *gin.Engine, deviceKey string, body []byte) (*http.Request, *httptest.ResponseRecorder) {
+	req, err := http.NewRequest(http.MethodPost, "/ingest", bytes.NewReader(body))
+	if err != nil {
+		panic(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.DeviceKeyHeader, deviceKey)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return req, w
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
@@ -33,6 +34,7 @@ func (suite *PondHandlerSuite) SetupSuite() {
 	// Initialize Configuration
 	test.SetupInitialize("../../.env")
 	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
 
 	// Initialize Router for testing
 	suite.Router = v1.Setup()
@@ -140,6 +142,136 @@ func (suite *PondHandlerSuite) TestDeleteById_Positive() {
 	a.Equal(http.StatusNoContent, w.Code, "HTTP request code error")
 }
 
+// Function to Update the area of pond but rejected because the active cycle would exceed the maximum density
+func (suite *PondHandlerSuite) TestUpdate_DensityExceeded() {
+	a := suite.Assert()
+	pond, err := repository.GetPondRepository().Create(models.Pond{Name: "stocked one", FarmId: 1, Type: models.PondTypeEarthen, Area: 1000})
+	a.NoError(err, "fail to insert resource")
+	_, err = repository.GetCycleRepository().Create(models.Cycle{
+		PondId:       pond.ID,
+		Species:      "Litopenaeus vannamei",
+		StockingDate: time.Now(),
+		InitialCount: 50000,
+		Status:       models.CycleStatusActive,
+	})
+	a.NoError(err, "fail to insert resource")
+
+	updateBody := map[string]interface{}{
+		"id":   pond.ID,
+		"area": 100,
+	}
+
+	requestBody, err := json.Marshal(updateBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := updatePond(suite.Router, bytes.NewBuffer(requestBody))
+	a.Equal(http.MethodPut, req.Method, "HTTP request method error")
+	a.Equal(http.StatusBadRequest, w.Code, "HTTP request status code error")
+}
+
+// Function to Create pond but rejected because the stocking count exceed the density of the pond type
+func (suite *PondHandlerSuite) TestCreatePond_DensityExceeded() {
+	farm, _ := insertFarm()
+	a := suite.Assert()
+
+	newBody := map[string]interface{}{
+		"name":           "crowded one",
+		"farm_id":        farm.ID,
+		"type":           models.PondTypeEarthen,
+		"area":           100,
+		"stocking_count": 50000,
+	}
+
+	requestBody, err := json.Marshal(newBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := createPond(suite.Router, bytes.NewBuffer(requestBody))
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusBadRequest, w.Code, "HTTP request status code error")
+}
+
+// Function to Update stocking count of pond, it replace the initial count of the active cycle
+func (suite *PondHandlerSuite) TestUpdate_StockingCount() {
+	a := suite.Assert()
+	pond, err := repository.GetPondRepository().Create(models.Pond{Name: "restocked one", FarmId: 1, Type: models.PondTypeEarthen, Area: 1000})
+	a.NoError(err, "fail to insert resource")
+	cycle, err := repository.GetCycleRepository().Create(models.Cycle{
+		PondId:       pond.ID,
+		Species:      "Litopenaeus vannamei",
+		StockingDate: time.Now(),
+		InitialCount: 50000,
+		Status:       models.CycleStatusActive,
+	})
+	a.NoError(err, "fail to insert resource")
+
+	updateBody := map[string]interface{}{
+		"id":             pond.ID,
+		"stocking_count": 40000,
+	}
+
+	requestBody, err := json.Marshal(updateBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := updatePond(suite.Router, bytes.NewBuffer(requestBody))
+	a.Equal(http.MethodPut, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNoContent, w.Code, "HTTP request status code error")
+
+	activeCycle, err := repository.GetCycleRepository().GetActiveByPond(pond.ID)
+	a.NoError(err, "pond should still have active cycle")
+	a.Equal(cycle.ID, activeCycle.ID, "active cycle should not change")
+	a.Equal(uint(40000), activeCycle.InitialCount, "stocking count should be the initial count of the active cycle")
+}
+
+// Function to Update the name of pond but rejected because it is inside withdrawal period
+func (suite *PondHandlerSuite) TestUpdate_Withdrawing() {
+	farm, _ := insertFarm()
+	pond, err := insertPond()
+	a := suite.Assert()
+
+	a.NotNil(pond, "fail to insert resource")
+	a.NoError(err, "fail to insert resource")
+	_, err = insertWithdrawingTreatment(pond.ID)
+	a.NoError(err, "fail to insert resource")
+
+	updateBody := models.Pond{
+		Model: gorm.Model{
+			ID: pond.ID,
+		},
+		Name:   "renamed while withdrawing",
+		FarmId: farm.ID,
+	}
+
+	requestBody, err := json.Marshal(updateBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := updatePond(suite.Router, bytes.NewBuffer(requestBody))
+	a.Equal(http.MethodPut, req.Method, "HTTP request method error")
+	a.Equal(http.StatusConflict, w.Code, "HTTP request status code error")
+}
+
+// Function to delete by id but rejected because it is inside withdrawal period
+func (suite *PondHandlerSuite) TestDeleteById_Withdrawing() {
+	pond, err := insertPond()
+	a := suite.Assert()
+
+	a.NotNil(pond, "fail to insert resource")
+	a.NoError(err, "fail to insert resource")
+	_, err = insertWithdrawingTreatment(pond.ID)
+	a.NoError(err, "fail to insert resource")
+
+	req, w := deletePondByIdRequest(suite.Router, pond.ID)
+	a.Equal(http.MethodDelete, req.Method, "HTTP request method error")
+	a.Equal(http.StatusConflict, w.Code, "HTTP request code error")
+}
+
 // Function to Update Non-Existing Resource
 // Therefore, it will create new Resource
 func (suite *PondHandlerSuite) TestUpdate_NonExisting() {
@@ -177,6 +309,7 @@ func createPond(r *gin.Engine, body *bytes.Buffer) (*http.Request, *httptest.Res
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -190,6 +323,7 @@ func getAllPondRequest(r *gin.Engine) (*http.Request, *httptest.ResponseRecorder
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -204,6 +338,7 @@ func getPondmByIdRequest(r *gin.Engine, pondId uint) (*http.Request, *httptest.R
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -217,6 +352,7 @@ func updatePond(r *gin.Engine, body *bytes.Buffer) (*http.Request, *httptest.Res
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -231,6 +367,7 @@ func deletePondByIdRequest(r *gin.Engine, pondId uint) (*http.Request, *httptest
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", test.GenerateBearerToken("1", models.RoleAdmin, 1))
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, req)
 	return req, w
@@ -241,3 +378,14 @@ func insertPond() (models.Pond, error) {
 	pondRepo := repository.GetPondRepository()
 	return pondRepo.Create(fixtures.WillBePond)
 }
+
+// Helper function insertWithdrawingTreatment, the pond is withdrawing for a week from now
+func insertWithdrawingTreatment(pondId uint) (models.TreatmentLog, error) {
+	treatmentRepo := repository.GetTreatmentLogRepository()
+	return treatmentRepo.Create(models.TreatmentLog{
+		PondId:         pondId,
+		Product:        "Oxytetracycline",
+		WithdrawalDays: 7,
+		TreatedAt:      time.Now(),
+	})
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecordApiHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestRecordApiHandler(t *testing.T) {
+	suite.Run(t, new(RecordApiHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *RecordApiHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+
+	// Initialize Router for testing
+	suite.Router = v1.Setup()
+}
+
+// Function to Get All Record but return unauthorized because there is no bearer token
+func (suite *RecordApiHandlerSuite) TestGetAllRecord_Unauthorized() {
+	a := suite.Assert()
+	req, w := getAllRecordRequest(suite.Router, "")
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusUnauthorized, w.Code, "HTTP request code error")
+}
+
+// Function to Get All Record but return forbidden because the role is not admin
+func (suite *RecordApiHandlerSuite) TestGetAllRecord_Forbidden() {
+	a := suite.Assert()
+	req, w := getAllRecordRequest(suite.Router, test.GenerateBearerToken("1", models.RoleFarmManager, 1))
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusForbidden, w.Code, "HTTP request code error")
+}
+
+// Helper function getAllRecord with the "Authorization" header, it is not set when empty
+func getAllRecordRequest(r *gin.Engine, authorization string) (*http.Request, *httptest.ResponseRecorder) {
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/records", nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return req, w
+}
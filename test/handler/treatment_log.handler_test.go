@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	v1 "github.com/adiatma85/golang-rest-template-api/internal/api/router/v1"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/validator"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type TreatmentLogHandlerSuite struct {
+	suite.Suite
+	Router *gin.Engine
+}
+
+func TestTreatmentLogHandler(t *testing.T) {
+	suite.Run(t, new(TreatmentLogHandlerSuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *TreatmentLogHandlerSuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	test.InsertFarmsAndPonds()
+
+	// Initialize Router for testing
+	suite.Router = v1.Setup()
+}
+
+// Function to Get All Treatment of pond of the tenant
+func (suite *TreatmentLogHandlerSuite) TestGetAllTreatment_Positive() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/pond/1/treatments", nil, 1)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusOK, w.Code, "HTTP request code error")
+}
+
+// Function to Create Treatment but return not found because the pond belong to other organization
+func (suite *TreatmentLogHandlerSuite) TestCreateTreatment_CrossTenant() {
+	a := suite.Assert()
+
+	newBody := validator.CreateTreatmentLogRequest{
+		Product:        "Oxytetracycline",
+		Dose:           2,
+		DoseUnit:       "g/kg feed",
+		WithdrawalDays: 7,
+	}
+
+	requestBody, err := json.Marshal(newBody)
+	if err != nil {
+		a.Error(err)
+	}
+
+	req, w := tenantRequest(suite.Router, http.MethodPost, "/api/v1/pond/1/treatments", requestBody, 2)
+	a.Equal(http.MethodPost, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Function to Delete Treatment but return not found because the pond belong to other organization
+func (suite *TreatmentLogHandlerSuite) TestDeleteTreatment_CrossTenant() {
+	treatment, err := insertWithdrawingTreatment(1)
+	a := suite.Assert()
+
+	a.NoError(err, "fail to insert resource")
+
+	url := fmt.Sprintf("/api/v1/pond/1/treatments/%d", treatment.ID)
+	req, w := tenantRequest(suite.Router, http.MethodDelete, url, nil, 2)
+	a.Equal(http.MethodDelete, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
+
+// Function to Get Harvest Clearance but return not found because the pond belong to other organization
+func (suite *TreatmentLogHandlerSuite) TestGetHarvestClearance_CrossTenant() {
+	a := suite.Assert()
+	req, w := tenantRequest(suite.Router, http.MethodGet, "/api/v1/pond/1/harvest-clearance", nil, 2)
+	a.Equal(http.MethodGet, req.Method, "HTTP request method error")
+	a.Equal(http.StatusNotFound, w.Code, "HTTP request code error")
+}
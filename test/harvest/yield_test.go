@@ -0,0 +1,74 @@
+package harvest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/harvest"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type YieldReportSuite struct {
+	suite.Suite
+	farm models.Farm
+}
+
+func TestYieldReport(t *testing.T) {
+	suite.Run(t, new(YieldReportSuite))
+}
+
+// Function to initialize the test suite
+func (suite *YieldReportSuite) SetupSuite() {
+	suite.farm = models.Farm{
+		Model: gorm.Model{ID: 1},
+		Name:  "Farm",
+		Ponds: []models.Pond{
+			{Model: gorm.Model{ID: 1}, Name: "Pond 1"},
+			{Model: gorm.Model{ID: 2}, Name: "Pond 2"},
+		},
+	}
+}
+
+// Helper to build harvest of pond some days after stocking
+func harvestOf(pondId uint, harvestType string, days int, totalWeight, pricePerKg float64) models.Harvest {
+	stockingDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	return models.Harvest{
+		PondId:      pondId,
+		Type:        harvestType,
+		Cycle:       models.Cycle{StockingDate: stockingDate},
+		HarvestedAt: stockingDate.AddDate(0, 0, days),
+		TotalWeight: totalWeight,
+		PricePerKg:  pricePerKg,
+	}
+}
+
+// Test yield is aggregated per pond and across the farm
+func (suite *YieldReportSuite) TestBuildYieldReport() {
+	harvests := []models.Harvest{
+		harvestOf(1, models.HarvestTypePartial, 60, 500, 4),
+		harvestOf(1, models.HarvestTypeFull, 100, 1500, 5),
+		harvestOf(2, models.HarvestTypeFull, 120, 1000, 5),
+	}
+	report := harvest.BuildYieldReport(suite.farm, harvests)
+
+	a := suite.Assert()
+	a.Equal(3, report.Harvests, "every harvest should be counted")
+	a.Equal(2, report.Cycles, "only full harvest should be counted as cycle")
+	a.InDelta(3000, report.TotalWeight, 0.0001, "harvested kilogram should be summed")
+	a.InDelta(14500, report.Revenue, 0.0001, "revenue should be weight times sale price")
+	a.InDelta(110, *report.AverageDaysOfCulture, 0.0001, "days of culture should be averaged over full harvest")
+	a.InDelta(2000, report.Ponds[0].TotalWeight, 0.0001, "harvested kilogram of pond should be summed")
+	a.InDelta(100, *report.Ponds[0].AverageDaysOfCulture, 0.0001, "partial harvest should not count as days of culture")
+}
+
+// Test pond without harvest still appear in the report
+func (suite *YieldReportSuite) TestBuildYieldReport_NoHarvest() {
+	report := harvest.BuildYieldReport(suite.farm, []models.Harvest{})
+
+	a := suite.Assert()
+	a.Len(report.Ponds, 2, "every pond of the farm should appear")
+	a.Nil(report.AverageDaysOfCulture, "days of culture should be nil without full harvest")
+	a.Equal(float64(0), report.Revenue, "revenue should be zero without harvest")
+}
@@ -0,0 +1,161 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/api/handler"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+// Helper to create check that always return the error
+func checkReturning(err error) health.CheckFunc {
+	return func(ctx context.Context) error {
+		return err
+	}
+}
+
+type HealthSuite struct {
+	suite.Suite
+}
+
+func TestHealth(t *testing.T) {
+	suite.Run(t, new(HealthSuite))
+}
+
+// Test the report is ok when every required check pass, even when optional check fail
+func (suite *HealthSuite) TestRun_OptionalFailed() {
+	registry := health.NewRegistry(time.Second)
+	registry.Register(health.Check{Name: "database", Check: checkReturning(nil)})
+	registry.Register(health.Check{Name: "storage", Optional: true, Check: checkReturning(errors.New("storage is unreachable"))})
+
+	report := registry.Run(context.Background())
+
+	a := suite.Assert()
+	a.Equal(health.StatusOk, report.Status, "failed optional check should not fail the report")
+	a.Equal(health.StatusOk, report.Checks["database"].Status)
+	a.Equal(health.StatusFail, report.Checks["storage"].Status, "failed optional check should still be reported")
+	a.Equal("storage is unreachable", report.Checks["storage"].Error)
+}
+
+// Test the report fail when required check fail
+func (suite *HealthSuite) TestRun_RequiredFailed() {
+	registry := health.NewRegistry(time.Second)
+	registry.Register(health.Check{Name: "database", Check: checkReturning(errors.New("connection refused"))})
+	registry.Register(health.Check{Name: "migration", Check: checkReturning(nil)})
+
+	report := registry.Run(context.Background())
+
+	a := suite.Assert()
+	a.Equal(health.StatusFail, report.Status, "failed required check should fail the report")
+	a.Len(report.Checks, 2, "every check should be reported")
+}
+
+// Test check that does not finish in time or panic is failed
+func (suite *HealthSuite) TestRun_TimeoutAndPanic() {
+	registry := health.NewRegistry(50 * time.Millisecond)
+	registry.Register(health.Check{Name: "slow", Check: func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}})
+	registry.Register(health.Check{Name: "broken", Check: func(ctx context.Context) error {
+		panic("nil pointer")
+	}})
+
+	startedAt := time.Now()
+	report := registry.Run(context.Background())
+
+	a := suite.Assert()
+	a.Less(time.Since(startedAt), 500*time.Millisecond, "slow check should be abandoned")
+	a.Equal(health.ErrCheckTimeout.Error(), report.Checks["slow"].Error)
+	a.Equal(health.StatusFail, report.Checks["broken"].Status, "panic check should be failed")
+}
+
+// Test check with the same name is replaced
+func (suite *HealthSuite) TestRegister_Replace() {
+	registry := health.NewRegistry(time.Second)
+	registry.Register(health.Check{Name: "database", Check: checkReturning(errors.New("connection refused"))})
+	registry.Register(health.Check{Name: "database", Check: checkReturning(nil)})
+	registry.Register(health.Check{Name: "mqtt", Optional: true, Check: checkReturning(nil)})
+	registry.Unregister("mqtt")
+
+	a := suite.Assert()
+	a.Equal([]string{"database"}, registry.Names())
+	a.Equal(health.StatusOk, registry.Run(context.Background()).Status, "check should be replaced")
+}
+
+// Test http check expect 2xx status with basic auth
+func (suite *HealthSuite) TestHttpGetCheck() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "key" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	a := suite.Assert()
+	a.NoError(health.HttpGetCheck(server.Client(), server.URL, "key", "secret")(context.Background()))
+	a.EqualError(health.HttpGetCheck(server.Client(), server.URL, "key", "wrong")(context.Background()), "unexpected status 401")
+}
+
+// Test cached check is called again only after the ttl
+func (suite *HealthSuite) TestCachedCheck() {
+	calls := 0
+	check := health.CachedCheck(func(ctx context.Context) error {
+		calls++
+		return errors.New("storage is unreachable")
+	}, 50*time.Millisecond)
+
+	a := suite.Assert()
+	a.Error(check(context.Background()))
+	a.Error(check(context.Background()), "cached failure should be returned")
+	a.Equal(1, calls, "check should not be called again before the ttl")
+
+	time.Sleep(60 * time.Millisecond)
+	a.Error(check(context.Background()))
+	a.Equal(2, calls, "check should be called again after the ttl")
+}
+
+// Test readiness endpoint response 503 with the status of checks when not ready
+func (suite *HealthSuite) TestReadiness() {
+	gin.SetMode(gin.TestMode)
+	registry := health.NewRegistry(time.Second)
+	healthHandler := &handler.HealthHandler{Registry: registry}
+	router := gin.New()
+	router.GET("/healthz", healthHandler.Liveness)
+	router.GET("/readyz", healthHandler.Readiness)
+
+	registry.Register(health.Check{Name: "database", Check: checkReturning(errors.New("connection refused"))})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+
+	var body struct {
+		Errors health.Report `json:"errors"`
+	}
+	a := suite.Assert()
+	a.Equal(http.StatusServiceUnavailable, w.Code, "api with dead database should not be ready")
+	a.NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	a.Equal(health.StatusFail, body.Errors.Checks["database"].Status, "failed check should be reported")
+	a.Empty(body.Errors.Checks["database"].Error, "error of failed check should not be exposed")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/healthz", nil)
+	router.ServeHTTP(w, req)
+	a.Equal(http.StatusOK, w.Code, "process should be alive even when it is not ready")
+
+	registry.Register(health.Check{Name: "database", Check: checkReturning(nil)})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/readyz", nil)
+	router.ServeHTTP(w, req)
+	a.Equal(http.StatusOK, w.Code, "api should be ready when database recover")
+}
@@ -0,0 +1,58 @@
+package helpers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type AquacultureHelperSuite struct {
+	suite.Suite
+}
+
+func TestAquacultureHelper(t *testing.T) {
+	suite.Run(t, new(AquacultureHelperSuite))
+}
+
+// Test feed conversion ratio with biomass gain
+func (suite *AquacultureHelperSuite) TestFeedConversionRatio_Positive() {
+	fcr, ok := helpers.FeedConversionRatio(150, 20, 120)
+
+	a := suite.Assert()
+	a.True(ok, "fcr should be defined when there is biomass gain")
+	a.InDelta(1.5, fcr, 0.0001, "fcr should be the feed divided by biomass gain")
+}
+
+// Test feed conversion ratio without biomass gain
+func (suite *AquacultureHelperSuite) TestFeedConversionRatio_NoGain() {
+	_, ok := helpers.FeedConversionRatio(150, 120, 120)
+	suite.Assert().False(ok, "fcr should be undefined when there is no biomass gain")
+
+	_, ok = helpers.FeedConversionRatio(150, 120, 100)
+	suite.Assert().False(ok, "fcr should be undefined when biomass is lost")
+}
+
+// Test daily growth rate from the initial average body weight
+func (suite *AquacultureHelperSuite) TestDailyGrowthRate() {
+	stockingDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	days := helpers.DaysOfCulture(stockingDate, stockingDate.AddDate(0, 0, 50))
+	rate, ok := helpers.DailyGrowthRate(0.01, 10.01, days)
+
+	a := suite.Assert()
+	a.InDelta(50, days, 0.0001, "days of culture should be counted from stocking date")
+	a.True(ok, "daily growth rate should be defined after stocking date")
+	a.InDelta(0.2, rate, 0.0001, "daily growth rate should be the weight gain divided by days")
+
+	_, ok = helpers.DailyGrowthRate(0.01, 0.01, 0)
+	a.False(ok, "daily growth rate should be undefined on stocking date")
+}
+
+// Test survival rate and standing biomass of estimated population
+func (suite *AquacultureHelperSuite) TestSurvivalRateAndBiomass() {
+	a := suite.Assert()
+	a.InDelta(80, helpers.SurvivalRate(80000, 100000), 0.0001, "survival rate should be percent of stocked count")
+	a.Equal(float64(0), helpers.SurvivalRate(10, 0), "survival rate of empty stocking should be zero")
+	a.InDelta(1200, helpers.Biomass(15, 80000), 0.0001, "biomass should be in kilogram")
+}
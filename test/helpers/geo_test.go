@@ -0,0 +1,34 @@
+package helpers
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/stretchr/testify/suite"
+)
+
+type GeoHelperSuite struct {
+	suite.Suite
+}
+
+func TestGeoHelper(t *testing.T) {
+	suite.Run(t, new(GeoHelperSuite))
+}
+
+// Test parsing bounding box in GeoJSON order
+func (suite *GeoHelperSuite) TestParseBoundingBox_Positive() {
+	box, err := helpers.ParseBoundingBox("112, -8,113,-7.5")
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when parsing valid bounding box")
+	a.Equal(helpers.BoundingBox{MinLongitude: 112, MinLatitude: -8, MaxLongitude: 113, MaxLatitude: -7.5}, *box, "bounding box should be min longitude, min latitude, max longitude, max latitude")
+}
+
+// Test parsing invalid bounding box
+func (suite *GeoHelperSuite) TestParseBoundingBox_Negative() {
+	a := suite.Assert()
+	for _, value := range []string{"", "112,-8,113", "a,-8,113,-7", "113,-8,112,-7", "112,-91,113,-7"} {
+		_, err := helpers.ParseBoundingBox(value)
+		a.ErrorIs(err, helpers.ErrInvalidBoundingBox, "bounding box %q should be invalid", value)
+	}
+}
@@ -0,0 +1,126 @@
+package metrics
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/metrics"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpositionSuite struct {
+	suite.Suite
+}
+
+func TestExposition(t *testing.T) {
+	suite.Run(t, new(ExpositionSuite))
+}
+
+// Helper to write the registry in the text format
+func (suite *ExpositionSuite) writeText(registry *metrics.Registry) string {
+	var buffer bytes.Buffer
+	suite.Require().NoError(registry.WriteText(&buffer), "should have no error when writing metrics")
+	return buffer.String()
+}
+
+// Test counter and gauge are written with sorted label values and escaped help and label value
+func (suite *ExpositionSuite) TestWriteText_CounterAndGauge() {
+	counter := metrics.NewCounterVec("requests_total", "Total of requests.\nBy path", "path")
+	counter.Inc("/b")
+	counter.Add(2, "/a\"quoted\"\\")
+	counter.Add(-1, "/b")
+	gauge := metrics.NewGaugeVec("in_flight", "Requests being served.")
+	gauge.Inc()
+	gauge.Inc()
+	gauge.Dec()
+
+	registry := metrics.NewRegistry()
+	registry.Register(gauge, counter)
+
+	expected := `# HELP in_flight Requests being served.
+# TYPE in_flight gauge
+in_flight 1
+# HELP requests_total Total of requests.\nBy path
+# TYPE requests_total counter
+requests_total{path="/a\"quoted\"\\"} 2
+requests_total{path="/b"} 1
+`
+	suite.Assert().Equal(expected, suite.writeText(registry), "families should be sorted by name and counter should not decrease")
+}
+
+// Test histogram buckets are cumulative with +Inf bucket, sum and count
+func (suite *ExpositionSuite) TestWriteText_Histogram() {
+	histogram := metrics.NewHistogramVec("duration_seconds", "Duration.", []float64{0.1, 1}, "method")
+	histogram.Observe(0.05, "GET")
+	histogram.Observe(0.1, "GET")
+	histogram.Observe(0.5, "GET")
+	histogram.Observe(3, "GET")
+
+	registry := metrics.NewRegistry()
+	registry.Register(histogram)
+
+	expected := `# HELP duration_seconds Duration.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{method="GET",le="0.1"} 2
+duration_seconds_bucket{method="GET",le="1"} 3
+duration_seconds_bucket{method="GET",le="+Inf"} 4
+duration_seconds_sum{method="GET"} 3.65
+duration_seconds_count{method="GET"} 4
+`
+	suite.Assert().Equal(expected, suite.writeText(registry), "value at the bound should be counted in the bucket")
+}
+
+// Test special values are spelled as in the format
+func (suite *ExpositionSuite) TestWriteText_SpecialValue() {
+	family := metrics.Family{Name: "special", Help: "Special.", Type: metrics.TypeGauge, Samples: []metrics.Sample{
+		{Name: "special", Labels: []metrics.Label{{Name: "kind", Value: "inf"}}, Value: math.Inf(1)},
+		{Name: "special", Labels: []metrics.Label{{Name: "kind", Value: "nan"}}, Value: math.NaN()},
+		{Name: "special", Labels: []metrics.Label{{Name: "kind", Value: "big"}}, Value: 1e21},
+	}}
+
+	var buffer bytes.Buffer
+	suite.Require().NoError(metrics.WriteText(&buffer, []metrics.Family{family}))
+	suite.Assert().Contains(buffer.String(), "special{kind=\"inf\"} +Inf\nspecial{kind=\"nan\"} NaN\nspecial{kind=\"big\"} 1e+21\n")
+}
+
+// Test invalid metric or label name is rejected
+func (suite *ExpositionSuite) TestWriteText_InvalidName() {
+	a := suite.Assert()
+	a.Error(metrics.WriteText(&bytes.Buffer{}, []metrics.Family{{Name: "1invalid", Type: metrics.TypeGauge}}), "metric name should not start with digit")
+	a.Error(metrics.WriteText(&bytes.Buffer{}, []metrics.Family{{Name: "valid", Type: metrics.TypeGauge, Samples: []metrics.Sample{
+		{Name: "valid", Labels: []metrics.Label{{Name: "__reserved", Value: "x"}}},
+	}}}), "label name should not be reserved")
+}
+
+// Test the same family can not be collected twice
+func (suite *ExpositionSuite) TestGather_Duplicate() {
+	registry := metrics.NewRegistry()
+	registry.Register(metrics.NewGaugeVec("dup", "Dup."), metrics.NewCounterVec("dup", "Dup."))
+
+	_, err := registry.Gather()
+	suite.Assert().Error(err, "duplicate family should be an error")
+}
+
+// Test pool statistics of database are collected, nothing when it is not available
+func (suite *ExpositionSuite) TestDBStatsCollector() {
+	collector := metrics.NewDBStatsCollector(func() (sql.DBStats, error) {
+		return sql.DBStats{MaxOpenConnections: 150, OpenConnections: 3, InUse: 1, Idle: 2, WaitDuration: 1500 * time.Millisecond}, nil
+	})
+	registry := metrics.NewRegistry()
+	registry.Register(collector)
+	output := suite.writeText(registry)
+
+	a := suite.Assert()
+	a.Contains(output, "db_max_open_connections 150\n")
+	a.Contains(output, "db_in_use_connections 1\n")
+	a.Contains(output, "# TYPE db_wait_duration_seconds_total counter\ndb_wait_duration_seconds_total 1.5\n")
+
+	unavailable := metrics.NewDBStatsCollector(func() (sql.DBStats, error) {
+		return sql.DBStats{}, errors.New("database is not connected")
+	})
+	a.Empty(unavailable.Collect(), "nothing should be collected when database is not available")
+}
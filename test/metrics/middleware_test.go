@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/api/middleware"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsMiddlewareSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	suite.Run(t, new(MetricsMiddlewareSuite))
+}
+
+// Function to initialize router with the metrics middleware
+func (suite *MetricsMiddlewareSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+	suite.router.Use(middleware.Metrics())
+	suite.router.GET("/farm/:farmId", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+}
+
+// Test requests are counted per route template instead of raw path
+func (suite *MetricsMiddlewareSuite) TestMetrics_RouteTemplate() {
+	for _, path := range []string{"/farm/1", "/farm/2", "/unknown"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		suite.router.ServeHTTP(w, req)
+	}
+
+	var buffer bytes.Buffer
+	suite.Require().NoError(metrics.GetRegistry().WriteText(&buffer))
+	output := buffer.String()
+
+	a := suite.Assert()
+	a.Contains(output, "http_requests_total{method=\"GET\",route=\"/farm/:farmId\",status=\"200\"} 2\n", "requests of the same route should be counted together")
+	a.Contains(output, "http_requests_total{method=\"GET\",route=\"unmatched\",status=\"404\"} 1\n", "request that match no route should be counted as unmatched")
+	a.Contains(output, "http_request_duration_seconds_count{method=\"GET\",route=\"/farm/:farmId\",status=\"200\"} 2\n", "latency of the requests should be observed")
+	a.Contains(output, "http_requests_in_flight 0\n", "finished request should not be in flight")
+}
+
+// Test metrics endpoint need the configured token, and is disabled without it
+func (suite *MetricsMiddlewareSuite) TestAuthMetrics() {
+	router := gin.New()
+	router.GET("/metrics", middleware.AuthMetrics("scrape-token"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	disabledRouter := gin.New()
+	disabledRouter.GET("/metrics", middleware.AuthMetrics(""), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	scrape := func(router *gin.Engine, authorization string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/metrics", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	a := suite.Assert()
+	a.Equal(http.StatusOK, scrape(router, "Bearer scrape-token"), "scrape with the token should be allowed")
+	a.Equal(http.StatusUnauthorized, scrape(router, ""), "scrape without token should be rejected")
+	a.Equal(http.StatusUnauthorized, scrape(router, "Bearer other-token"), "scrape with other token should be rejected")
+	a.Equal(http.StatusNotFound, scrape(disabledRouter, "Bearer "), "metrics should be disabled without configured token")
+}
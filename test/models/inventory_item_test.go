@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type InventoryItemModelSuite struct {
+	suite.Suite
+}
+
+func TestInventoryItemModel(t *testing.T) {
+	suite.Run(t, new(InventoryItemModelSuite))
+}
+
+// Test item is low on stock at or below its threshold
+func (suite *InventoryItemModelSuite) TestIsLowStock() {
+	threshold := 100.0
+	item := models.InventoryItem{LowStockThreshold: &threshold}
+
+	a := suite.Assert()
+	a.True(item.IsLowStock(100), "balance at the threshold should be low on stock")
+	a.False(item.IsLowStock(100.5), "balance above the threshold should not be low on stock")
+	a.False(models.InventoryItem{}.IsLowStock(0), "item without threshold should never be low on stock")
+}
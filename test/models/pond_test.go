@@ -0,0 +1,82 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type PondModelSuite struct {
+	suite.Suite
+}
+
+func TestPondModel(t *testing.T) {
+	suite.Run(t, new(PondModelSuite))
+}
+
+// Test stocking density of earthen pond is measured per m²
+func (suite *PondModelSuite) TestStockingDensity_Area() {
+	pond := models.Pond{Type: models.PondTypeEarthen, Area: 2000, Depth: 1.2}
+	density, ok := pond.StockingDensity(100000)
+
+	a := suite.Assert()
+	a.True(ok, "density should be defined when area is known")
+	a.InDelta(50, density, 0.0001, "density of earthen pond should be per m²")
+}
+
+// Test stocking density of tank is measured per m³, volume is computed when it is not set
+func (suite *PondModelSuite) TestStockingDensity_Volume() {
+	a := suite.Assert()
+
+	tank := models.Pond{Type: models.PondTypeTank, Area: 20, Depth: 1.5}
+	density, ok := tank.StockingDensity(6000)
+	a.True(ok, "density should be defined when area and depth is known")
+	a.InDelta(200, density, 0.0001, "density of tank should be per m³ of area times depth")
+
+	ras := models.Pond{Type: models.PondTypeRas, Area: 20, Depth: 1.5, Volume: 40}
+	density, _ = ras.StockingDensity(6000)
+	a.InDelta(150, density, 0.0001, "configured volume should be used")
+}
+
+// Test stocking density of pond without dimension
+func (suite *PondModelSuite) TestStockingDensity_Unknown() {
+	_, ok := models.Pond{Type: models.PondTypeTank, Area: 20}.StockingDensity(6000)
+	suite.Assert().False(ok, "density should be undefined when the volume is unknown")
+}
+
+// Test only the attributes that the density is measured by change the stocking density
+func (suite *PondModelSuite) TestStockingDensityChanged() {
+	pond := models.Pond{Name: "P1", Type: models.PondTypeEarthen, Area: 100, Depth: 1.5}
+	a := suite.Assert()
+
+	renamed := pond
+	renamed.Name = "P2"
+	a.False(renamed.StockingDensityChanged(pond), "renaming should not change the density")
+
+	deepened := pond
+	deepened.Depth = 2
+	a.True(deepened.StockingDensityChanged(pond), "changing the depth should change the volume")
+
+	lined := pond
+	lined.Type = models.PondTypeLined
+	a.True(lined.StockingDensityChanged(pond), "changing the type should change the maximum density")
+}
+
+// Test outline polygon must be closed with valid positions
+func (suite *PondModelSuite) TestOutlineValidate() {
+	a := suite.Assert()
+
+	outline := models.Polygon{{{112.75, -7.25}, {112.76, -7.25}, {112.76, -7.26}, {112.75, -7.25}}}
+	a.NoError(outline.Validate(), "closed ring should be valid")
+	a.NoError(models.Polygon(nil).Validate(), "empty outline should be valid")
+
+	notClosed := models.Polygon{{{112.75, -7.25}, {112.76, -7.25}, {112.76, -7.26}, {112.75, -7.26}}}
+	a.Error(notClosed.Validate(), "ring that is not closed should be invalid")
+
+	tooShort := models.Polygon{{{112.75, -7.25}, {112.76, -7.25}, {112.75, -7.25}}}
+	a.Error(tooShort.Validate(), "ring with less than 4 positions should be invalid")
+
+	outOfRange := models.Polygon{{{190, -7.25}, {112.76, -7.25}, {112.76, -7.26}, {190, -7.25}}}
+	a.Error(outOfRange.Validate(), "position out of range should be invalid")
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type RecordApiModelSuite struct {
+	suite.Suite
+}
+
+func TestRecordApiModel(t *testing.T) {
+	suite.Run(t, new(RecordApiModelSuite))
+}
+
+// Test latency is counted in the first bucket whose bound is not exceeded
+func (suite *RecordApiModelSuite) TestObserve() {
+	var histogram models.LatencyHistogram
+	histogram.Observe(5)
+	histogram.Observe(5.1)
+	histogram.Observe(60000)
+
+	a := suite.Assert()
+	a.Equal(uint(1), histogram.Le5ms, "latency at the bound should be counted in the bucket")
+	a.Equal(uint(1), histogram.Le10ms, "latency above the bound should be counted in the next bucket")
+	a.Equal(uint(1), histogram.LeInf, "latency above every bound should be counted in the last bucket")
+	a.Len(histogram.Counts(), len(models.LatencyHistogramColumns), "every bucket should have a column")
+}
+
+// Test histograms are added per bucket
+func (suite *RecordApiModelSuite) TestAdd() {
+	histogram := models.LatencyHistogram{Le5ms: 1, LeInf: 2}
+	histogram.Add(models.LatencyHistogram{Le5ms: 3, Le100ms: 4})
+
+	suite.Assert().Equal([]uint{4, 0, 0, 0, 4, 0, 0, 0, 0, 0, 0, 2}, histogram.Counts(), "counts should be added per bucket")
+}
+
+// Test custom method is recorded as other method, so it fits the column
+func (suite *RecordApiModelSuite) TestNormalizeMethod() {
+	a := suite.Assert()
+	a.Equal("PATCH", models.NormalizeMethod("PATCH"), "standard method should be kept")
+	a.Equal(models.RecordApiOtherMethod, models.NormalizeMethod("PROPFINDXXXX"), "custom method should be other method")
+	a.Equal(models.RecordApiOtherMethod, models.NormalizeMethod("get"), "method is case sensitive")
+}
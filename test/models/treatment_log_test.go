@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/stretchr/testify/suite"
+)
+
+type TreatmentLogModelSuite struct {
+	suite.Suite
+}
+
+func TestTreatmentLogModel(t *testing.T) {
+	suite.Run(t, new(TreatmentLogModelSuite))
+}
+
+// Test withdrawal period starts at the treatment and lasts the withdrawal days
+func (suite *TreatmentLogModelSuite) TestIsWithdrawing() {
+	treatment := models.TreatmentLog{WithdrawalDays: 7, TreatedAt: time.Date(2022, 3, 5, 0, 0, 0, 0, time.UTC)}
+
+	a := suite.Assert()
+	a.Equal(time.Date(2022, 3, 12, 0, 0, 0, 0, time.UTC), treatment.WithdrawalEndsAt(), "withdrawal should end after the withdrawal days")
+	a.True(treatment.IsWithdrawing(time.Date(2022, 3, 11, 23, 0, 0, 0, time.UTC)), "pond should be withdrawing before the end")
+	a.False(treatment.IsWithdrawing(treatment.WithdrawalEndsAt()), "pond should be cleared at the end")
+	a.False(models.TreatmentLog{TreatedAt: treatment.TreatedAt}.IsWithdrawing(treatment.TreatedAt), "treatment without withdrawal days should never be withdrawing")
+}
@@ -0,0 +1,68 @@
+package mortality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/mortality"
+	"github.com/stretchr/testify/suite"
+)
+
+type MortalitySpikeSuite struct {
+	suite.Suite
+	stockingDate time.Time
+}
+
+func TestMortalitySpike(t *testing.T) {
+	suite.Run(t, new(MortalitySpikeSuite))
+}
+
+// Function to initialize the test suite
+func (suite *MortalitySpikeSuite) SetupSuite() {
+	suite.stockingDate = time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Helper to build mortality log some hours after stocking
+func (suite *MortalitySpikeSuite) log(hours int, count uint, cause string) models.MortalityLog {
+	return models.MortalityLog{Count: count, Cause: cause, RecordedAt: suite.stockingDate.Add(time.Duration(hours) * time.Hour)}
+}
+
+// Test daily dead stock below the threshold is not a spike
+func (suite *MortalitySpikeSuite) TestDetectSpikes_NoSpike() {
+	logs := []models.MortalityLog{suite.log(24, 50, ""), suite.log(48, 60, ""), suite.log(72, 40, "")}
+	spikes := mortality.DetectSpikes(logs, 10000, 24*time.Hour, 1)
+
+	suite.Assert().Empty(spikes, "dead stock below 1 percent per day should not be a spike")
+}
+
+// Test logs inside one window are summed and overlapping windows are merged
+func (suite *MortalitySpikeSuite) TestDetectSpikes_Merged() {
+	logs := []models.MortalityLog{
+		suite.log(24, 20, ""),
+		suite.log(100, 60, "low oxygen"),
+		suite.log(110, 50, "low oxygen"),
+		suite.log(120, 80, "disease"),
+		suite.log(240, 10, ""),
+	}
+	spikes := mortality.DetectSpikes(logs, 10000, 24*time.Hour, 1)
+
+	a := suite.Assert()
+	a.Len(spikes, 1, "overlapping windows should be merged into one spike")
+	a.Equal(logs[1].RecordedAt, spikes[0].Start, "spike should start at the first log of the window")
+	a.Equal(logs[3].RecordedAt, spikes[0].End, "spike should end at the last log of the window")
+	a.Equal(uint(190), spikes[0].Deaths, "deaths of the spike should be summed")
+	a.Equal(uint(9980), spikes[0].AliveAtStart, "alive stock should exclude the dead stock before the spike")
+	a.Equal(uint(110), spikes[0].Causes["low oxygen"], "deaths should be grouped by cause")
+}
+
+// Test separated spikes and unsorted logs
+func (suite *MortalitySpikeSuite) TestDetectSpikes_Separated() {
+	logs := []models.MortalityLog{suite.log(300, 200, ""), suite.log(24, 150, "")}
+	spikes := mortality.DetectSpikes(logs, 10000, 24*time.Hour, 1)
+
+	a := suite.Assert()
+	a.Len(spikes, 2, "windows that do not overlap should be separated spikes")
+	a.Equal(logs[1].RecordedAt, spikes[0].Start, "spikes should be ordered by time")
+	a.InDelta(200.0/9850*100, spikes[1].MortalityRate, 0.0001, "mortality rate should be percent of alive stock")
+}
@@ -0,0 +1,245 @@
+package mqtt
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/alert"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/mqtt"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/telemetry"
+	"github.com/adiatma85/golang-rest-template-api/pkg/crypto"
+	broker "github.com/mochi-co/mqtt/server"
+	"github.com/mochi-co/mqtt/server/listeners"
+	"github.com/mochi-co/mqtt/server/listeners/auth"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+const topic = "farms/+/ponds/+/readings"
+
+// Api key of device 3 that is registered to pond 2, and of device 4 that is registered to pond 5
+const (
+	deviceKey          = "device-3-key"
+	otherPondDeviceKey = "device-4-key"
+)
+
+// Pond 2 of farm 1 with device 3 registered to it
+type fakePondRepository struct {
+	repository.PondRepositoryInterface
+}
+
+func (repo *fakePondRepository) GetByModel(where models.Pond) (*models.Pond, error) {
+	if where.ID != 2 || where.FarmId != 1 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &where, nil
+}
+
+type fakeDeviceRepository struct {
+	repository.DeviceRepositoryInterface
+}
+
+func (repo *fakeDeviceRepository) GetByApiKeyHash(apiKeyHash string) (*models.Device, error) {
+	switch apiKeyHash {
+	case crypto.GetDeviceKeyCryptoHelper().HashDeviceKey(deviceKey):
+		device := models.Device{PondId: 2}
+		device.ID = 3
+		return &device, nil
+	case crypto.GetDeviceKeyCryptoHelper().HashDeviceKey(otherPondDeviceKey):
+		device := models.Device{PondId: 5}
+		device.ID = 4
+		return &device, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *fakeDeviceRepository) UpdateLastSeen(device *models.Device, seenAt time.Time) error {
+	return nil
+}
+
+// Stored batches are sent to the channel, measurement of the same device and timestamp is stored once
+type fakeMeasurementRepository struct {
+	repository.MeasurementRepositoryInterface
+	stored chan []models.Measurement
+	mutex  sync.Mutex
+	keys   map[string]bool
+}
+
+func (repo *fakeMeasurementRepository) CreateBatch(measurements []models.Measurement) ([]models.Measurement, error) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+
+	newMeasurements := []models.Measurement{}
+	for _, measurement := range measurements {
+		key := fmt.Sprintf("%d/%d", measurement.DeviceId, measurement.MeasuredAt.UnixNano())
+		if repo.keys[key] {
+			continue
+		}
+		repo.keys[key] = true
+		newMeasurements = append(newMeasurements, measurement)
+	}
+	if len(newMeasurements) > 0 {
+		repo.stored <- newMeasurements
+	}
+	return newMeasurements, nil
+}
+
+// Evaluated batches are sent to the channel
+type fakeAlertEngine struct {
+	alert.EngineInterface
+	evaluated chan []models.Measurement
+}
+
+func (engine *fakeAlertEngine) EvaluateMeasurements(measurements []models.Measurement) ([]models.Alert, error) {
+	engine.evaluated <- measurements
+	return nil, nil
+}
+
+type SubscriberSuite struct {
+	suite.Suite
+	broker          *broker.Server
+	subscriber      *mqtt.Subscriber
+	measurementRepo *fakeMeasurementRepository
+	alertEngine     *fakeAlertEngine
+}
+
+func TestSubscriber(t *testing.T) {
+	suite.Run(t, new(SubscriberSuite))
+}
+
+// Function to start embedded broker and subscriber connected to it
+func (suite *SubscriberSuite) SetupSuite() {
+	address := freeAddress(suite)
+	suite.broker = broker.NewServer(nil)
+	err := suite.broker.AddListener(listeners.NewTCP("tcp", address), &listeners.Config{Auth: new(auth.Allow)})
+	suite.Require().NoError(err, "embedded broker should listen")
+	go suite.broker.Serve()
+
+	suite.measurementRepo = &fakeMeasurementRepository{stored: make(chan []models.Measurement, 10), keys: map[string]bool{}}
+	suite.subscriber = mqtt.NewSubscriber(config.MqttConfiguration{
+		BrokerUrl: "tcp://" + address,
+		ClientId:  "subscriber-test",
+		Topic:     topic,
+		Qos:       1,
+	}, 10)
+	suite.subscriber.PondRepository = &fakePondRepository{}
+	suite.subscriber.DeviceRepository = &fakeDeviceRepository{}
+	suite.subscriber.MeasurementRepository = suite.measurementRepo
+	suite.alertEngine = &fakeAlertEngine{evaluated: make(chan []models.Measurement, 10)}
+	suite.subscriber.AlertEngine = suite.alertEngine
+
+	suite.Require().NoError(suite.subscriber.Start(), "subscriber should connect and subscribe to embedded broker")
+}
+
+// Function to stop subscriber and embedded broker
+func (suite *SubscriberSuite) TearDownSuite() {
+	suite.subscriber.Stop()
+	suite.broker.Close()
+}
+
+// Test message published to the broker is stored as measurements of the pond in the topic
+func (suite *SubscriberSuite) TestPublish_Stored() {
+	payload := `{"device_key": "` + deviceKey + `", "readings": [
+		{"measured_at": "2022-02-10T06:00:00Z", "dissolved_oxygen": 5.5},
+		{"measured_at": "2022-02-10T06:01:00Z", "ph": 7.8}
+	]}`
+	a := suite.Assert()
+	a.NoError(suite.broker.Publish("farms/1/ponds/2/readings", []byte(payload), false), "broker should publish message")
+
+	select {
+	case measurements := <-suite.measurementRepo.stored:
+		a.Len(measurements, 2, "every reading should be stored")
+		a.Equal(uint(2), measurements[0].PondId, "measurement should belong to the pond of the topic")
+		a.Equal(uint(3), measurements[0].DeviceId, "measurement should belong to the device of the key")
+		a.Equal(5.5, *measurements[0].DissolvedOxygen, "reading should be mapped to measurement")
+	case <-time.After(5 * time.Second):
+		a.Fail("message should be stored")
+	}
+
+	select {
+	case measurements := <-suite.alertEngine.evaluated:
+		a.Len(measurements, 2, "stored readings should be evaluated")
+	case <-time.After(5 * time.Second):
+		a.Fail("stored readings should be evaluated")
+	}
+}
+
+// Test resent reading is not stored nor evaluated again
+func (suite *SubscriberSuite) TestHandleMessage_Duplicate() {
+	payload := []byte(`{"device_key": "` + deviceKey + `", "readings": [{"measured_at": "2022-02-11T06:00:00Z", "ph": 9.5}]}`)
+	a := suite.Assert()
+
+	accepted, err := suite.subscriber.HandleMessage("farms/1/ponds/2/readings", payload)
+	a.NoError(err, "new reading should be accepted")
+	a.Equal(int64(1), accepted, "new reading should be stored")
+	<-suite.measurementRepo.stored
+	<-suite.alertEngine.evaluated
+
+	accepted, err = suite.subscriber.HandleMessage("farms/1/ponds/2/readings", payload)
+	a.NoError(err, "resent reading should not be an error")
+	a.Equal(int64(0), accepted, "resent reading should not be stored again")
+	a.Empty(suite.measurementRepo.stored, "resent reading should not be stored again")
+	a.Empty(suite.alertEngine.evaluated, "resent reading should not be evaluated again")
+}
+
+// Test message of unknown pond or device is not stored
+func (suite *SubscriberSuite) TestHandleMessage_Rejected() {
+	reading := `"readings": [{"measured_at": "2022-02-10T06:00:00Z", "ph": 7.8}]`
+	a := suite.Assert()
+
+	_, err := suite.subscriber.HandleMessage("farms/9/ponds/2/readings", []byte(`{"device_key": "`+deviceKey+`", `+reading+`}`))
+	a.ErrorIs(err, mqtt.ErrInvalidTopic, "pond of another farm should be rejected")
+
+	_, err = suite.subscriber.HandleMessage("farms/1/ponds/2/readings", []byte(`{"device_key": "unknown-key", `+reading+`}`))
+	a.ErrorIs(err, mqtt.ErrInvalidDeviceKey, "unknown device key should be rejected")
+
+	_, err = suite.subscriber.HandleMessage("farms/1/ponds/2/readings", []byte(`{"device_key": "`+otherPondDeviceKey+`", `+reading+`}`))
+	a.Error(err, "device that is not registered to the pond should be rejected")
+
+	a.Empty(suite.measurementRepo.stored, "rejected message should not be stored")
+	a.Empty(suite.alertEngine.evaluated, "rejected message should not be evaluated")
+}
+
+// Test parsing farm and pond id from the topic
+func (suite *SubscriberSuite) TestParseTopic() {
+	a := suite.Assert()
+
+	farmId, pondId, err := mqtt.ParseTopic(topic, "farms/1/ponds/2/readings")
+	a.NoError(err, "matching topic should be parsed")
+	a.Equal(uint(1), farmId, "the first wildcard should be the farm id")
+	a.Equal(uint(2), pondId, "the second wildcard should be the pond id")
+
+	for _, invalid := range []string{"farms/1/ponds/2", "farms/a/ponds/2/readings", "farm/1/ponds/2/readings", "farms/0/ponds/2/readings"} {
+		_, _, err := mqtt.ParseTopic(topic, invalid)
+		a.ErrorIs(err, mqtt.ErrInvalidTopic, "topic %q should be invalid", invalid)
+	}
+}
+
+// Test payload with invalid reading is rejected
+func (suite *SubscriberSuite) TestParsePayload_Negative() {
+	a := suite.Assert()
+	for _, payload := range []string{
+		`not json`,
+		`{"readings": [{"measured_at": "2022-02-10T06:00:00Z", "ph": 7.8}]}`,
+		`{"device_key": "key", "readings": []}`,
+		`{"device_key": "key", "readings": [{"ph": 7.8}]}`,
+		`{"device_key": "key", "readings": [{"measured_at": "2022-02-10T06:00:00Z", "ph": 15}]}`,
+	} {
+		_, _, err := mqtt.ParsePayload([]byte(payload))
+		a.ErrorIs(err, telemetry.ErrInvalidReading, "payload %s should be invalid", payload)
+	}
+}
+
+// Helper to find free local address for the embedded broker
+func freeAddress(suite *SubscriberSuite) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err, "should find free port")
+	defer listener.Close()
+	return listener.Addr().String()
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type CycleRepositorySuite struct {
+	suite.Suite
+	cycleRepo repository.CycleRepositoryInterface
+}
+
+func TestCycleRepository(t *testing.T) {
+	suite.Run(t, new(CycleRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *CycleRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.cycleRepo = repository.GetCycleRepository()
+
+	// Cycle can not be an orphan
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data
+	for _, cycle := range fixtures.Cycles {
+		suite.cycleRepo.Create(cycle)
+	}
+}
+
+// Get All Cycle of Pond Test, the latest stocking first
+func (suite *CycleRepositorySuite) TestGetAllByPond_Positive() {
+	cycles, err := suite.cycleRepo.GetAllByPond(1)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching cycles of pond")
+	a.Len(*cycles, 2, "only cycles of the pond should be fetched")
+	a.Equal(models.CycleStatusPlanned, (*cycles)[0].Status, "the latest stocking cycle should be the first")
+}
+
+// Get Active Cycle of Pond Test
+func (suite *CycleRepositorySuite) TestGetActiveByPond_Positive() {
+	cycle, err := suite.cycleRepo.GetActiveByPond(1)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when pond has active cycle")
+	a.Equal(models.CycleStatusActive, cycle.Status, "fetched cycle should be the active one")
+}
+
+// Get Active Cycle of Pond Test (Negative)
+func (suite *CycleRepositorySuite) TestGetActiveByPond_Negative() {
+	cycle, err := suite.cycleRepo.GetActiveByPond(2)
+
+	a := suite.Assert()
+	a.Nil(cycle, "pond without active cycle should return nil")
+	a.ErrorIs(err, gorm.ErrRecordNotFound, "pond without active cycle should return record not found")
+}
+
+// Save Active Cycle Test (Negative), pond can not have two active cycles
+func (suite *CycleRepositorySuite) TestSaveActive_Conflict() {
+	plannedCycle, err := suite.cycleRepo.GetByModel(models.Cycle{PondId: 1, Status: models.CycleStatusPlanned})
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching planned cycle")
+
+	err = suite.cycleRepo.SaveActive(plannedCycle)
+	a.ErrorIs(err, repository.ErrActiveCycleExists, "pond that already has active cycle should not be stocked again")
+
+	where := models.Cycle{}
+	where.ID = plannedCycle.ID
+	storedCycle, err := suite.cycleRepo.GetByModel(where)
+	a.NoError(err, "should have no error when fetching the cycle")
+	a.Equal(models.CycleStatusPlanned, storedCycle.Status, "rejected cycle should stay planned")
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
 	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
 	"github.com/adiatma85/golang-rest-template-api/test"
 	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
 	"github.com/stretchr/testify/suite"
@@ -27,6 +28,7 @@ func (suite *FarmRepositorySuite) SetupSuite() {
 	// Initialize Configuration
 	test.SetupInitialize("../../.env")
 	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
 	suite.farmRepo = repository.GetFarmRepository()
 
 	// inserting dummy data
@@ -54,6 +56,62 @@ func (suite *FarmRepositorySuite) TestGetAllFarm_Positive() {
 	a.NoError(err, "should have no error when fetching farms (bulk fetch)")
 }
 
+// Get All Farm instances with Pagination Test
+func (suite *FarmRepositorySuite) TestGetAllPaginated_Positive() {
+	pagination, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{Limit: 2, Sort: "name"}, repository.FarmFilter{Name: "farm"})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching farms (paginated fetch)")
+	a.Equal("name asc", pagination.Sort, "sort should be normalized")
+	a.LessOrEqual(len(*pagination.Rows.(*[]models.Farm)), 2, "rows should be limited")
+	a.NotZero(pagination.TotalRows, "total rows should be counted")
+}
+
+// Get All Farm instances inside Bounding Box Test, farm without location is excluded
+func (suite *FarmRepositorySuite) TestGetAllPaginated_BoundingBox() {
+	boundingBox := helpers.BoundingBox{MinLongitude: 112, MinLatitude: -8, MaxLongitude: 113, MaxLatitude: -7}
+	pagination, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{}, repository.FarmFilter{BoundingBox: &boundingBox})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching farms (bounding box fetch)")
+	a.Equal(int64(1), pagination.TotalRows, "only farm located inside the bounding box should be fetched")
+}
+
+// Get All Farm instances with Cursor Pagination Test, walk forward then backward
+func (suite *FarmRepositorySuite) TestGetAllPaginated_Cursor() {
+	a := suite.Assert()
+
+	first, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{Limit: 1, Sort: "id", CursorMode: true}, repository.FarmFilter{})
+	a.NoError(err, "should have no error when fetching farms (cursor fetch)")
+	a.NotEmpty(first.NextCursor, "first page should have next cursor")
+	a.Empty(first.PrevCursor, "first page should not have prev cursor")
+
+	second, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{Limit: 1, Sort: "id", CursorMode: true, Cursor: first.NextCursor}, repository.FarmFilter{})
+	a.NoError(err, "should have no error when fetching farms (cursor fetch)")
+	firstRows, secondRows := *first.Rows.(*[]models.Farm), *second.Rows.(*[]models.Farm)
+	a.Less(firstRows[0].ID, secondRows[0].ID, "second page should continue after the first page")
+
+	back, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{Limit: 1, Sort: "id", CursorMode: true, Cursor: second.PrevCursor}, repository.FarmFilter{})
+	a.NoError(err, "should have no error when fetching farms (cursor fetch)")
+	a.Equal(firstRows[0].ID, (*back.Rows.(*[]models.Farm))[0].ID, "prev cursor should go back to the first page")
+}
+
+// Get All Farm instances with Cursor Pagination Test (invalid cursor)
+func (suite *FarmRepositorySuite) TestGetAllPaginated_InvalidCursor() {
+	_, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{CursorMode: true, Cursor: "not-a-cursor"}, repository.FarmFilter{})
+
+	a := suite.Assert()
+	a.ErrorIs(err, helpers.ErrInvalidCursor, "cursor that can not be decoded must be rejected")
+}
+
+// Get All Farm instances with Pagination Test (invalid sort)
+func (suite *FarmRepositorySuite) TestGetAllPaginated_InvalidSort() {
+	_, err := suite.farmRepo.GetAllPaginated(helpers.Pagination{Sort: "id; drop table farms"}, repository.FarmFilter{})
+
+	a := suite.Assert()
+	a.ErrorIs(err, helpers.ErrInvalidSort, "sort field that is not allowed must be rejected")
+}
+
 // Test Get Farm from id
 func (suite *FarmRepositorySuite) TestGetById_Positive() {
 	farm, err := suite.farmRepo.GetById("1")
@@ -75,6 +133,15 @@ func (suite *FarmRepositorySuite) TestGetById_Negative() {
 	a.Nil(nonExistentFarm, "the resource shoul have not exist or nil")
 }
 
+// Test Get Farm from id of another tenant (Negative)
+func (suite *FarmRepositorySuite) TestGetById_OtherTenant() {
+	farm, err := suite.farmRepo.WithTenant([]uint{2}).GetById("1")
+	a := suite.Assert()
+
+	a.ErrorIs(err, gorm.ErrRecordNotFound, "farm of another organization must not be found")
+	a.Nil(farm, "the resource shoul have not exist or nil")
+}
+
 // Test Get Farm by defined model struct
 func (suite *FarmRepositorySuite) TestGetByModel_Positive() {
 	where := models.Farm{
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+)
+
+type FeedingLogRepositorySuite struct {
+	suite.Suite
+	feedingRepo repository.FeedingLogRepositoryInterface
+}
+
+func TestFeedingLogRepository(t *testing.T) {
+	suite.Run(t, new(FeedingLogRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *FeedingLogRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.feedingRepo = repository.GetFeedingLogRepository()
+
+	// Feeding log can not be an orphan
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data
+	for _, feedingLog := range fixtures.FeedingLogs {
+		suite.feedingRepo.Create(feedingLog)
+	}
+}
+
+// Get All Feeding Log of Pond filtered by feed type Test
+func (suite *FeedingLogRepositorySuite) TestGetAllByPond_FeedType() {
+	filter := repository.FeedingLogFilter{FeedType: "starter"}
+	pagination, err := suite.feedingRepo.GetAllByPond(1, helpers.Pagination{}, filter)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching feeding logs (feed type fetch)")
+	a.Equal(int64(2), pagination.TotalRows, "only feeding log with the feed type should be fetched")
+}
+
+// Sum Feeding Log of Pond grouped by feed type Test
+func (suite *FeedingLogRepositorySuite) TestSumByPond_Positive() {
+	totals, err := suite.feedingRepo.SumByPond(1, time.Time{}, time.Time{})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when summing feeding logs")
+	a.Len(totals, 2, "totals should be grouped by feed type")
+	a.Equal("grower", totals[0].FeedType, "totals should be ordered by feed type")
+	a.InDelta(5.5, totals[1].Quantity, 0.0001, "starter quantity should be summed")
+	a.Equal(int64(2), totals[1].Feedings, "starter feedings should be counted")
+}
+
+// Sum Feeding Log of Pond in time range Test
+func (suite *FeedingLogRepositorySuite) TestSumByPond_TimeRange() {
+	from := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 3, 1, 23, 59, 59, 0, time.UTC)
+	totals, err := suite.feedingRepo.SumByPond(1, from, to)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when summing feeding logs (time range)")
+	a.Len(totals, 1, "only feeding log inside the time range should be summed")
+	a.InDelta(5.5, totals[0].Quantity, 0.0001, "starter quantity inside the time range should be summed")
+}
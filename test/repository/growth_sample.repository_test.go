@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type GrowthSampleRepositorySuite struct {
+	suite.Suite
+	sampleRepo repository.GrowthSampleRepositoryInterface
+}
+
+func TestGrowthSampleRepository(t *testing.T) {
+	suite.Run(t, new(GrowthSampleRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *GrowthSampleRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.sampleRepo = repository.GetGrowthSampleRepository()
+
+	// Sample can not be an orphan
+	test.InsertFarmsAndPonds()
+	cycleRepo := repository.GetCycleRepository()
+	for _, cycle := range fixtures.Cycles {
+		cycleRepo.Create(cycle)
+	}
+
+	// inserting dummy data
+	for _, sample := range fixtures.GrowthSamples {
+		suite.sampleRepo.Create(sample)
+	}
+}
+
+// Get All Growth Sample of Cycle Test
+func (suite *GrowthSampleRepositorySuite) TestGetAllByPond_Cycle() {
+	filter := repository.GrowthSampleFilter{CycleId: 1}
+	pagination, err := suite.sampleRepo.GetAllByPond(1, helpers.Pagination{}, filter)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching samples (cycle fetch)")
+	a.Equal(int64(2), pagination.TotalRows, "every sample of the cycle should be fetched")
+}
+
+// Get the latest Growth Sample of Cycle Test
+func (suite *GrowthSampleRepositorySuite) TestGetLatestByCycle_Positive() {
+	sample, err := suite.sampleRepo.GetLatestByCycle(1)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when cycle has sample")
+	a.Equal(fixtures.GrowthSamples[1].SampledAt.Unix(), sample.SampledAt.Unix(), "the latest sample should be fetched")
+	a.InDelta(10, sample.AverageBodyWeight(), 0.0001, "average body weight should be total weight divided by sample count")
+}
+
+// Get the latest Growth Sample of Cycle Test (Negative)
+func (suite *GrowthSampleRepositorySuite) TestGetLatestByCycle_Negative() {
+	sample, err := suite.sampleRepo.GetLatestByCycle(3)
+
+	a := suite.Assert()
+	a.Nil(sample, "cycle without sample should return nil")
+	a.ErrorIs(err, gorm.ErrRecordNotFound, "cycle without sample should return record not found")
+}
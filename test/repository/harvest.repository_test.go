@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+)
+
+type HarvestRepositorySuite struct {
+	suite.Suite
+	harvestRepo repository.HarvestRepositoryInterface
+}
+
+func TestHarvestRepository(t *testing.T) {
+	suite.Run(t, new(HarvestRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *HarvestRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.harvestRepo = repository.GetHarvestRepository()
+
+	// Harvest can not be an orphan
+	test.InsertFarmsAndPonds()
+	cycleRepo := repository.GetCycleRepository()
+	for _, cycle := range fixtures.Cycles {
+		cycleRepo.Create(cycle)
+	}
+
+	// inserting dummy data
+	for _, harvest := range fixtures.Harvests {
+		suite.harvestRepo.Create(harvest)
+	}
+}
+
+// Full Harvest end the cycle and mark the pond as empty Test
+func (suite *HarvestRepositorySuite) TestCreate_FullHarvest() {
+	where := models.Cycle{}
+	where.ID = 1
+	cycle, err := repository.GetCycleRepository().GetByModel(where)
+	pond, _ := repository.GetPondRepository().GetById("1")
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching the harvested cycle")
+	a.Equal(models.CycleStatusHarvested, cycle.Status, "full harvest should end the cycle as harvested")
+	a.NotNil(cycle.EndedAt, "full harvest should set the end of the cycle")
+	a.Equal(models.PondOccupancyEmpty, pond.Occupancy, "full harvest should mark the pond as empty")
+}
+
+// Get All Harvest of Ponds in time range Test
+func (suite *HarvestRepositorySuite) TestGetAllByPonds_TimeRange() {
+	from := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	harvests, err := suite.harvestRepo.GetAllByPonds([]uint{1, 2}, from, time.Time{})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching harvests of ponds")
+	a.Len(*harvests, 1, "only harvest inside the time range should be fetched")
+	a.Equal(fixtures.Cycles[0].StockingDate.Unix(), (*harvests)[0].Cycle.StockingDate.Unix(), "cycle of the harvest should be loaded")
+}
+
+// Sum harvested quantity of Cycle Test
+func (suite *HarvestRepositorySuite) TestSumQuantityByCycle() {
+	quantity, err := suite.harvestRepo.SumQuantityByCycle(1)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when summing harvested quantity")
+	a.Equal(uint(80000), quantity, "harvested quantity of the cycle should be summed")
+
+	quantity, _ = suite.harvestRepo.SumQuantityByCycle(3)
+	a.Equal(uint(0), quantity, "cycle without harvest should have zero harvested quantity")
+}
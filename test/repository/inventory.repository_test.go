@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+)
+
+type InventoryRepositorySuite struct {
+	suite.Suite
+	itemRepo     repository.InventoryItemRepositoryInterface
+	movementRepo repository.StockMovementRepositoryInterface
+}
+
+func TestInventoryRepository(t *testing.T) {
+	suite.Run(t, new(InventoryRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *InventoryRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.itemRepo = repository.GetInventoryItemRepository()
+	suite.movementRepo = repository.GetStockMovementRepository()
+
+	// Item can not be an orphan
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data
+	for _, item := range fixtures.InventoryItems {
+		suite.itemRepo.Create(item)
+	}
+	for _, movement := range fixtures.StockMovements {
+		suite.movementRepo.Create(movement)
+	}
+}
+
+// Get the on-hand balance of Inventory Items Test, item without movement has zero balance
+func (suite *InventoryRepositorySuite) TestGetBalances() {
+	balances, err := suite.itemRepo.GetBalances([]uint{1, 2})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when computing balances")
+	a.InDelta(50, balances[1], 0.0001, "balance should be the sum of the movements")
+	a.Zero(balances[2], "item without movement should have zero balance")
+}
+
+// Get All Inventory Item of Farm that is low on stock Test
+func (suite *InventoryRepositorySuite) TestGetAllByFarm_LowStock() {
+	filter := repository.InventoryItemFilter{LowStock: true}
+	pagination, err := suite.itemRepo.GetAllByFarm(1, helpers.Pagination{}, filter)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching items (low stock fetch)")
+	a.Equal(int64(1), pagination.TotalRows, "only item at or below its threshold should be fetched")
+}
+
+// Create Stock Movement that take more than the on-hand balance Test
+func (suite *InventoryRepositorySuite) TestCreate_InsufficientStock() {
+	movement := models.StockMovement{
+		ItemId:   2,
+		Type:     models.StockMovementConsumption,
+		Quantity: -10,
+		MovedAt:  time.Now(),
+	}
+	_, err := suite.movementRepo.Create(movement)
+
+	a := suite.Assert()
+	a.ErrorIs(err, repository.ErrInsufficientStock, "consumption should not take the stock below zero")
+}
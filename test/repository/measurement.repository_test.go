@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/aggregate"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+)
+
+type MeasurementRepositorySuite struct {
+	suite.Suite
+	measurementRepo repository.MeasurementRepositoryInterface
+}
+
+func TestMeasurementRepository(t *testing.T) {
+	suite.Run(t, new(MeasurementRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *MeasurementRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.measurementRepo = repository.GetMeasurementRepository()
+
+	// Measurement can not be an orphan
+	test.InsertFarmsAndPonds()
+	deviceRepo := repository.GetDeviceRepository()
+	for _, device := range fixtures.Devices {
+		deviceRepo.Create(device)
+	}
+
+	// inserting dummy data
+	suite.measurementRepo.CreateBatch(fixtures.Measurements)
+}
+
+// Aggregate Measurement of Pond into hourly buckets Test, new measurement invalidate the cached bucket
+func (suite *MeasurementRepositorySuite) TestAggregate_Hourly() {
+	from := time.Date(2022, 2, 10, 5, 0, 0, 0, time.UTC)
+	to := time.Date(2022, 2, 10, 7, 0, 0, 0, time.UTC)
+	query, _ := aggregate.NewQuery("do", aggregate.FunctionAvg, "1h", from, to)
+	buckets, err := suite.measurementRepo.Aggregate(1, query)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when aggregating measurements")
+	a.Len(buckets, 2, "every bucket in the range should be returned")
+	a.Nil(buckets[0].Value, "bucket without measurement should have no value")
+	a.Equal(int64(2), buckets[1].Count, "measurements in the bucket should be counted")
+	a.InDelta(5.45, *buckets[1].Value, 0.0001, "value should be the average of the bucket")
+
+	value := 5.0
+	suite.measurementRepo.CreateBatch([]models.Measurement{
+		{PondId: 1, DeviceId: 2, MeasuredAt: time.Date(2022, 2, 10, 6, 30, 0, 0, time.UTC), DissolvedOxygen: &value},
+	})
+	buckets, err = suite.measurementRepo.Aggregate(1, query)
+	a.NoError(err, "should have no error when aggregating measurements again")
+	a.Equal(int64(3), buckets[1].Count, "new measurement should not be hidden by the cache")
+}
+
+// Store batch that is partly sent before Test, only the new measurement is stored
+func (suite *MeasurementRepositorySuite) TestCreateBatch_Idempotent() {
+	value := 7.8
+	newMeasurement := models.Measurement{
+		PondId:     1,
+		DeviceId:   1,
+		MeasuredAt: time.Date(2022, 2, 10, 6, 2, 0, 0, time.UTC),
+		Ph:         &value,
+	}
+	stored, err := suite.measurementRepo.CreateBatch(append([]models.Measurement{newMeasurement, newMeasurement}, fixtures.Measurements...))
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when batch contains measurement that is already stored")
+	a.Len(stored, 1, "only the new measurement should be stored, once")
+	a.Equal(newMeasurement.MeasuredAt, stored[0].MeasuredAt, "the new measurement should be returned")
+}
+
+// Same timestamp from another device is not a duplicate Test
+func (suite *MeasurementRepositorySuite) TestCreateBatch_AnotherDevice() {
+	measurement := fixtures.Measurements[0]
+	measurement.DeviceId = 2
+	stored, err := suite.measurementRepo.CreateBatch([]models.Measurement{measurement})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when storing measurement of another device")
+	a.Len(stored, 1, "measurement of another device on the same timestamp should be stored")
+}
+
+// Get All Measurement of Pond by time range Test
+func (suite *MeasurementRepositorySuite) TestGetAllByPond_TimeRange() {
+	filter := repository.MeasurementFilter{
+		DeviceId: 1,
+		From:     time.Date(2022, 2, 10, 6, 0, 30, 0, time.UTC),
+		To:       time.Date(2022, 2, 10, 6, 1, 30, 0, time.UTC),
+	}
+	pagination, err := suite.measurementRepo.GetAllByPond(1, helpers.Pagination{}, filter)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching measurements (time range fetch)")
+	a.Equal(int64(1), pagination.TotalRows, "only measurement inside time range should be fetched")
+}
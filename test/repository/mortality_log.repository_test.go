@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+)
+
+type MortalityLogRepositorySuite struct {
+	suite.Suite
+	mortalityRepo repository.MortalityLogRepositoryInterface
+}
+
+func TestMortalityLogRepository(t *testing.T) {
+	suite.Run(t, new(MortalityLogRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *MortalityLogRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.mortalityRepo = repository.GetMortalityLogRepository()
+
+	// Mortality log can not be an orphan
+	test.InsertFarmsAndPonds()
+	cycleRepo := repository.GetCycleRepository()
+	for _, cycle := range fixtures.Cycles {
+		cycleRepo.Create(cycle)
+	}
+
+	// inserting dummy data
+	for _, mortalityLog := range fixtures.MortalityLogs {
+		suite.mortalityRepo.Create(mortalityLog)
+	}
+}
+
+// Get All Mortality Log of Cycle Test, the oldest first
+func (suite *MortalityLogRepositorySuite) TestGetAllByCycle_Positive() {
+	mortalityLogs, err := suite.mortalityRepo.GetAllByCycle(1)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching mortality logs of cycle")
+	a.Len(*mortalityLogs, 3, "every mortality log of the cycle should be fetched")
+	a.Equal(uint(300), (*mortalityLogs)[0].Count, "the oldest mortality log should be the first")
+}
+
+// Sum Mortality Log of Cycle grouped by cause Test
+func (suite *MortalityLogRepositorySuite) TestSumByCycle_Positive() {
+	totals, err := suite.mortalityRepo.SumByCycle(1)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when summing mortality logs")
+	a.Len(totals, 2, "totals should be grouped by cause")
+	a.Equal("disease", totals[0].Cause, "totals should be ordered by cause")
+	a.Equal(uint(500), totals[1].Count, "dead stock of the cause should be summed")
+}
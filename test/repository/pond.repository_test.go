@@ -27,6 +27,7 @@ func (suite *PondRepositorySuite) SetupSuite() {
 	// Initialize Configuration
 	test.SetupInitialize("../../.env")
 	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
 	suite.pondRepo = repository.GetPondRepository()
 
 	// Need Farm repo because pond can not be an orphan
@@ -84,6 +85,15 @@ func (suite *PondRepositorySuite) TestGetById_Negative() {
 	a.Nil(nonExistentPond, "the resource shoul have not exist or nil")
 }
 
+// Test Get All Pond of another tenant (Negative)
+func (suite *PondRepositorySuite) TestGetAllPond_OtherTenant() {
+	ponds, err := suite.pondRepo.WithTenant([]uint{2}).GetAll()
+
+	a := suite.Assert()
+	a.Empty(*ponds, "ponds of another organization must not be fetched")
+	a.NoError(err, "should have no error when fetching ponds (bulk fetch)")
+}
+
 // Test Get Pond by defined model struct
 func (suite *PondRepositorySuite) TestGetByModel_Positive() {
 	where := models.Pond{
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/stretchr/testify/suite"
+)
+
+var trafficHour = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+type RecordApiRepositorySuite struct {
+	suite.Suite
+	recordRepo repository.RecordApiRepositoryInterface
+}
+
+func TestRecordApiRepository(t *testing.T) {
+	suite.Run(t, new(RecordApiRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *RecordApiRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	suite.recordRepo = repository.GetRecordApiRepository()
+
+	// The farm route is flushed twice in the same hour, so it is upserted
+	farmRecord := models.RecordApi{BucketStart: trafficHour, Method: "GET", Route: "/api/v1/farm", Status: 200, Count: 2, LatencySumMs: 30, LatencyMaxMs: 20}
+	farmRecord.LatencyHistogram.Le25ms = 2
+	suite.recordRepo.IncrementCounts([]models.RecordApi{
+		farmRecord,
+		{BucketStart: trafficHour, Method: "GET", Route: "/api/v1/pond", Status: 404, Count: 1},
+	})
+	farmRecord.LatencyMaxMs = 80
+	farmRecord.LatencyHistogram.Le25ms = 0
+	farmRecord.LatencyHistogram.Le100ms = 2
+	suite.recordRepo.IncrementCounts([]models.RecordApi{farmRecord})
+}
+
+// Increment Counts of existing record Test
+func (suite *RecordApiRepositorySuite) TestIncrementCounts_Upsert() {
+	record, err := suite.recordRepo.GetByModel(models.RecordApi{BucketStart: trafficHour, Method: "GET", Route: "/api/v1/farm", Status: 200})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching upserted record")
+	a.Equal(uint(4), record.Count, "count should be added to the existing record")
+	a.Equal(80.0, record.LatencyMaxMs, "max latency should be the greatest")
+	a.Equal(uint(2), record.LatencyHistogram.Le25ms, "histogram should be added per bucket")
+	a.Equal(uint(2), record.LatencyHistogram.Le100ms, "histogram should be added per bucket")
+}
+
+// Get Record grouped by status Test, the busiest first
+func (suite *RecordApiRepositorySuite) TestGetGrouped_Status() {
+	groups, err := suite.recordRepo.GetGrouped(repository.RecordApiGroupByStatus, repository.RecordApiFilter{From: trafficHour})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when grouping records")
+	a.Len(groups, 2, "records should be grouped by status")
+	a.Equal(200, groups[0].Status, "the busiest status should be the first")
+	a.Equal(uint(4), groups[0].Count, "count of the status should be summed")
+}
+
+// Get Record grouped by route outside the time range Test
+func (suite *RecordApiRepositorySuite) TestGetGrouped_OutOfRange() {
+	groups, err := suite.recordRepo.GetGrouped(repository.RecordApiGroupByRoute, repository.RecordApiFilter{To: trafficHour.Add(-time.Hour)})
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when grouping records")
+	a.Empty(groups, "record outside the time range should not be grouped")
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+)
+
+type TreatmentLogRepositorySuite struct {
+	suite.Suite
+	treatmentRepo repository.TreatmentLogRepositoryInterface
+}
+
+func TestTreatmentLogRepository(t *testing.T) {
+	suite.Run(t, new(TreatmentLogRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *TreatmentLogRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.treatmentRepo = repository.GetTreatmentLogRepository()
+
+	// Treatment log can not be an orphan
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data
+	for _, treatmentLog := range fixtures.TreatmentLogs {
+		suite.treatmentRepo.Create(treatmentLog)
+	}
+}
+
+// Get Treatment Log of Pond inside withdrawal period Test, the latest ending first
+func (suite *TreatmentLogRepositorySuite) TestGetWithdrawingByPond_Positive() {
+	treatmentLogs, err := suite.treatmentRepo.GetWithdrawingByPond(1, time.Date(2022, 3, 11, 0, 0, 0, 0, time.UTC))
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching withdrawing treatment logs")
+	a.Len(*treatmentLogs, 2, "treatment without withdrawal period should not be fetched")
+	a.Equal("Oxytetracycline", (*treatmentLogs)[0].Product, "the latest ending treatment should be the first")
+}
+
+// Get Treatment Log of Pond after every withdrawal period is over Test
+func (suite *TreatmentLogRepositorySuite) TestGetWithdrawingByPond_Cleared() {
+	treatmentLogs, err := suite.treatmentRepo.GetWithdrawingByPond(1, time.Date(2022, 3, 22, 0, 0, 0, 0, time.UTC))
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching withdrawing treatment logs")
+	a.Empty(*treatmentLogs, "pond should be cleared when the withdrawal period is over")
+}
+
+// Get Treatment Log of Pond before any treatment Test
+func (suite *TreatmentLogRepositorySuite) TestGetWithdrawingByPond_BeforeTreatment() {
+	treatmentLogs, err := suite.treatmentRepo.GetWithdrawingByPond(1, time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC))
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching withdrawing treatment logs")
+	a.Empty(*treatmentLogs, "treatment after the time should not be fetched")
+}
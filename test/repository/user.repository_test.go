@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type UserRepositorySuite struct {
+	suite.Suite
+	userRepo repository.UserRepositoryInterface
+}
+
+func TestUserRepository(t *testing.T) {
+	suite.Run(t, new(UserRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *UserRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	suite.userRepo = repository.GetUserRepository()
+
+	// inserting dummy data
+	for _, user := range fixtures.Users {
+		suite.userRepo.Create(user)
+	}
+}
+
+// Create User instance Test
+func (suite *UserRepositorySuite) TestCreateUser_Positive() {
+	createdUser, err := suite.userRepo.Create(fixtures.WillBeUser)
+
+	a := suite.Assert()
+	a.Equal(fixtures.WillBeUser.Email, createdUser.Email, "both of the email from dummy data and existed user should have the same value")
+	a.NoError(err, "should have no error when creating new user with this parameter")
+}
+
+// Create User with duplicate email Test
+func (suite *UserRepositorySuite) TestCreateUser_Negative() {
+	_, err := suite.userRepo.Create(fixtures.Users[0])
+
+	a := suite.Assert()
+	a.Error(err, "should have an error when creating user with duplicate email")
+}
+
+// Test Get User from id
+func (suite *UserRepositorySuite) TestGetById_Positive() {
+	user, err := suite.userRepo.GetById("1")
+	a := suite.Assert()
+
+	a.Equal(uint(1), user.ID, "both of the id from client data and existed user should have the same value")
+	a.Equal(fixtures.Users[0].Email, user.Email, "both of the email from dummy data and existed user should have the same value")
+	a.NoError(err, "should have no error when fetching user (singular fetch by id)")
+}
+
+// Test Get User (Negative)
+func (suite *UserRepositorySuite) TestGetById_Negative() {
+	nonExistentUser, err := suite.userRepo.GetById("1000")
+	a := suite.Assert()
+
+	a.Error(err, "should have an error when fetching user (singular fetch by id)")
+	a.ErrorIs(err, gorm.ErrRecordNotFound, "the type of error must be error not found")
+	a.Nil(nonExistentUser, "the resource shoul have not exist or nil")
+}
+
+// Test Get User by defined model struct
+func (suite *UserRepositorySuite) TestGetByModel_Positive() {
+	where := models.User{
+		Email: fixtures.Users[1].Email,
+	}
+
+	user, err := suite.userRepo.GetByModel(where)
+	a := suite.Assert()
+
+	a.Equal(where.Email, user.Email, "both of the email from dummy data and existed user should have the same value")
+	a.NoError(err, "should have no error when fetching user (singular fetch by defined struct)")
+}
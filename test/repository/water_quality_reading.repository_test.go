@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/db"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/pkg/helpers"
+	"github.com/adiatma85/golang-rest-template-api/test"
+	"github.com/adiatma85/golang-rest-template-api/test/fixtures"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+type WaterQualityReadingRepositorySuite struct {
+	suite.Suite
+	readingRepo repository.WaterQualityReadingRepositoryInterface
+}
+
+func TestWaterQualityReadingRepository(t *testing.T) {
+	suite.Run(t, new(WaterQualityReadingRepositorySuite))
+	defer test.TearDownHelper()
+}
+
+// Function to initialize the test suite
+func (suite *WaterQualityReadingRepositorySuite) SetupSuite() {
+	// Initialize Configuration
+	test.SetupInitialize("../../.env")
+	db.SetupTestingDb(test.Host, test.Username, test.Password, test.Port, test.Database)
+	test.InsertOrganizations()
+	suite.readingRepo = repository.GetWaterQualityReadingRepository()
+
+	// Reading can not be an orphan
+	test.InsertFarmsAndPonds()
+
+	// inserting dummy data
+	for _, reading := range fixtures.WaterQualityReadings {
+		suite.readingRepo.Create(reading)
+	}
+}
+
+// Create Water Quality Reading instance Test
+func (suite *WaterQualityReadingRepositorySuite) TestCreateReading_Positive() {
+	reading := fixtures.WaterQualityReadings[0]
+	reading.MeasuredAt = time.Now()
+	createdReading, err := suite.readingRepo.Create(reading)
+
+	a := suite.Assert()
+	a.Equal(*reading.DissolvedOxygen, *createdReading.DissolvedOxygen, "both of the dissolved oxygen from dummy data and existed reading should have the same value")
+	a.Nil(createdReading.Salinity, "unmeasured parameter should stay nil")
+	a.NoError(err, "should have no error when creating new reading with this parameter")
+}
+
+// Get All Water Quality Reading of Pond in time range Test
+func (suite *WaterQualityReadingRepositorySuite) TestGetAllByPond_TimeRange() {
+	filter := repository.WaterQualityReadingFilter{
+		From: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	pagination, err := suite.readingRepo.GetAllByPond(1, helpers.Pagination{}, filter)
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when fetching readings (time range fetch)")
+	a.Equal(int64(1), pagination.TotalRows, "only reading inside the time range should be fetched")
+}
+
+// Test Get Water Quality Reading (Negative)
+func (suite *WaterQualityReadingRepositorySuite) TestGetById_Negative() {
+	nonExistentReading, err := suite.readingRepo.GetById(fmt.Sprint(1000))
+	a := suite.Assert()
+
+	a.ErrorIs(err, gorm.ErrRecordNotFound, "the type of error must be error not found")
+	a.Nil(nonExistentReading, "the resource shoul have not exist or nil")
+}
+
+// Test Delete Existing Resource
+func (suite *WaterQualityReadingRepositorySuite) TestDeleteReading_Positive() {
+	a := suite.Assert()
+	reading := models.WaterQualityReading{
+		Model: gorm.Model{
+			ID: 3,
+		},
+	}
+
+	err := suite.readingRepo.Delete(&reading)
+	a.NoError(err, "should have no error when deleting reading")
+}
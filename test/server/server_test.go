@@ -0,0 +1,120 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/api"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerSuite struct {
+	suite.Suite
+}
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(ServerSuite))
+}
+
+// Helper to run server of the handler on free port, the error of run is sent to the channel
+func (suite *ServerSuite) runServer(ctx context.Context, handler http.Handler, shutdownTimeout time.Duration) (string, chan error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err, "should listen on free port")
+
+	server := api.NewServer(handler, config.ServerConnection{ReadTimeoutSecond: 5, WriteTimeoutSecond: 5})
+	server.ShutdownTimeout = shutdownTimeout
+
+	ran := make(chan error, 1)
+	go func() {
+		ran <- server.Run(ctx, listener)
+	}()
+	return "http://" + listener.Addr().String(), ran
+}
+
+// Test the server is built from the configuration
+func (suite *ServerSuite) TestNewServer() {
+	server := api.NewServer(http.NotFoundHandler(), config.ServerConnection{
+		Port:                    "5000",
+		ReadTimeoutSecond:       15,
+		ReadHeaderTimeoutSecond: 5,
+		WriteTimeoutSecond:      30,
+		IdleTimeoutSecond:       60,
+		ShutdownTimeoutSecond:   10,
+		MaxHeaderBytes:          1024,
+	})
+
+	a := suite.Assert()
+	a.Equal(":5000", server.Addr)
+	a.Equal(15*time.Second, server.ReadTimeout)
+	a.Equal(5*time.Second, server.ReadHeaderTimeout)
+	a.Equal(30*time.Second, server.WriteTimeout)
+	a.Equal(60*time.Second, server.IdleTimeout)
+	a.Equal(10*time.Second, server.ShutdownTimeout)
+	a.Equal(1024, server.MaxHeaderBytes)
+}
+
+// Test the in-flight request is finished before the server stop
+func (suite *ServerSuite) TestRun_Drain() {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	url, ran := suite.runServer(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}), 5*time.Second)
+
+	responded := make(chan int, 1)
+	go func() {
+		response, err := http.Get(url)
+		if err != nil {
+			responded <- 0
+			return
+		}
+		response.Body.Close()
+		responded <- response.StatusCode
+	}()
+
+	<-started
+	cancel()
+
+	a := suite.Assert()
+	a.Equal(http.StatusOK, <-responded, "in-flight request should not be cut")
+	a.NoError(<-ran, "server should stop without error after draining")
+
+	_, err := http.Get(url)
+	a.Error(err, "server should not accept new connection after stopped")
+}
+
+// Test the server is closed with error when the request does not finish before the deadline
+func (suite *ServerSuite) TestRun_Deadline() {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	url, ran := suite.runServer(ctx, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	}), 100*time.Millisecond)
+
+	go http.Get(url)
+	<-started
+	cancel()
+
+	select {
+	case err := <-ran:
+		suite.Assert().ErrorIs(err, context.DeadlineExceeded, "deadline error should be returned")
+	case <-time.After(2 * time.Second):
+		suite.Fail("server should be closed after the deadline")
+	}
+}
+
+// Test TLS needs both cert and key file
+func (suite *ServerSuite) TestRun_IncompleteTls() {
+	server := api.NewServer(http.NotFoundHandler(), config.ServerConnection{TlsCertFile: "server.crt"})
+
+	suite.Assert().ErrorIs(server.ListenAndRun(context.Background()), api.ErrIncompleteTls)
+}
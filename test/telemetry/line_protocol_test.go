@@ -0,0 +1,66 @@
+package telemetry
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/telemetry"
+	"github.com/stretchr/testify/suite"
+)
+
+type LineProtocolSuite struct {
+	suite.Suite
+}
+
+func TestLineProtocol(t *testing.T) {
+	suite.Run(t, new(LineProtocolSuite))
+}
+
+// Test parsing readings, comment and empty line are skipped
+func (suite *LineProtocolSuite) TestParseLineProtocol_Positive() {
+	body := strings.Join([]string{
+		"# probe of pond 1",
+		"water,pond=1 dissolved_oxygen=5.5,temperature=28 1644472800",
+		"",
+		"water ph=8i 1644472860",
+	}, "\n")
+	measurements, err := telemetry.ParseLineProtocol(strings.NewReader(body), "s")
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when parsing valid readings")
+	a.Len(measurements, 2, "every reading line should be parsed")
+	a.Equal(time.Date(2022, 2, 10, 6, 0, 0, 0, time.UTC), measurements[0].MeasuredAt, "timestamp should follow the precision")
+	a.Equal(5.5, *measurements[0].DissolvedOxygen, "field should be set to its parameter")
+	a.Equal(28.0, *measurements[0].Temperature, "field should be set to its parameter")
+	a.Nil(measurements[0].Ph, "unsent parameter should be nil")
+	a.Equal(8.0, *measurements[1].Ph, "integer field should be parsed")
+}
+
+// Test default precision is nanosecond
+func (suite *LineProtocolSuite) TestParseLineProtocol_DefaultPrecision() {
+	measurements, err := telemetry.ParseLineProtocol(strings.NewReader("water ph=7.5 1644472800000000000"), "")
+
+	a := suite.Assert()
+	a.NoError(err, "should have no error when parsing valid reading")
+	a.Equal(time.Date(2022, 2, 10, 6, 0, 0, 0, time.UTC), measurements[0].MeasuredAt, "timestamp should be in nanosecond")
+}
+
+// Test parsing invalid readings
+func (suite *LineProtocolSuite) TestParseLineProtocol_Negative() {
+	a := suite.Assert()
+	for _, line := range []string{
+		"water ph=7.5",
+		"water ph=7.5 now",
+		"water ph 1644472800",
+		"water ph=high 1644472800",
+		"water turbidity=3 1644472800",
+		"water ph=15 1644472800",
+	} {
+		_, err := telemetry.ParseLineProtocol(strings.NewReader(line), "s")
+		a.ErrorIs(err, telemetry.ErrInvalidReading, "line %q should be invalid", line)
+	}
+
+	_, err := telemetry.ParseLineProtocol(strings.NewReader("water ph=7.5 1644472800"), "m")
+	a.ErrorIs(err, telemetry.ErrInvalidReading, "unknown precision should be invalid")
+}
@@ -0,0 +1,193 @@
+package traffic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/config"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/repository"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/traffic"
+	"github.com/stretchr/testify/suite"
+)
+
+// Written records are summed per route, the write fails while failing is set
+type fakeRecordApiRepository struct {
+	repository.RecordApiRepositoryInterface
+	mutex   sync.Mutex
+	failing bool
+	flushes int
+	records []models.RecordApi
+	counts  map[string]uint
+}
+
+func (repo *fakeRecordApiRepository) IncrementCounts(records []models.RecordApi) error {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	if repo.failing {
+		return errors.New("database is unreachable")
+	}
+	repo.flushes++
+	repo.records = append(repo.records, records...)
+	for _, record := range records {
+		repo.counts[record.Route] += record.Count
+	}
+	return nil
+}
+
+func (repo *fakeRecordApiRepository) snapshot() (int, map[string]uint) {
+	repo.mutex.Lock()
+	defer repo.mutex.Unlock()
+	counts := map[string]uint{}
+	for path, count := range repo.counts {
+		counts[path] = count
+	}
+	return repo.flushes, counts
+}
+
+var hour = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+type CollectorSuite struct {
+	suite.Suite
+	recordRepo *fakeRecordApiRepository
+}
+
+func TestCollector(t *testing.T) {
+	suite.Run(t, new(CollectorSuite))
+}
+
+// Function to reset the written counts before each test
+func (suite *CollectorSuite) SetupTest() {
+	suite.recordRepo = &fakeRecordApiRepository{counts: map[string]uint{}}
+}
+
+// Helper to create collector that write to the fake repository
+func (suite *CollectorSuite) newCollector(flushInterval int, maxPending int) *traffic.Collector {
+	collector := traffic.NewCollector(config.RecordApiConfiguration{FlushIntervalSecond: flushInterval, MaxPending: maxPending})
+	collector.RecordApiRepository = suite.recordRepo
+	return collector
+}
+
+// Test concurrent requests to the same key are counted without losing any of them, and drained on stop
+func (suite *CollectorSuite) TestRecord_Concurrent() {
+	collector := suite.newCollector(3600, 0)
+	collector.Start()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				collector.Record(traffic.Key{BucketStart: hour, Route: "/api/v1/farm", Status: 200}, time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+	collector.Stop()
+
+	flushes, counts := suite.recordRepo.snapshot()
+	a := suite.Assert()
+	a.Equal(1, flushes, "pending traffic should be written once when stopped")
+	a.Equal(uint(1000), counts["/api/v1/farm"], "every request should be counted")
+}
+
+// Test the collector flush early when the pending keys reach the maximum
+func (suite *CollectorSuite) TestRecord_MaxPending() {
+	collector := suite.newCollector(3600, 2)
+	collector.Start()
+	defer collector.Stop()
+
+	collector.Record(traffic.Key{BucketStart: hour, Route: "/api/v1/farm", Status: 200}, time.Millisecond)
+	collector.Record(traffic.Key{BucketStart: hour, Route: "/api/v1/pond", Status: 200}, time.Millisecond)
+
+	suite.Eventually(func() bool {
+		flushes, _ := suite.recordRepo.snapshot()
+		return flushes == 1
+	}, time.Second, 10*time.Millisecond, "pending traffic should be written before the flush interval")
+}
+
+// Test the counts are kept when writing fails, and written by the next flush
+func (suite *CollectorSuite) TestFlush_Failed() {
+	collector := suite.newCollector(3600, 0)
+	collector.Record(traffic.Key{BucketStart: hour, Route: "/api/v1/farm", Status: 200}, time.Millisecond)
+
+	suite.recordRepo.failing = true
+	a := suite.Assert()
+	a.Error(collector.Flush(), "error of the repository should be returned")
+
+	collector.Record(traffic.Key{BucketStart: hour, Route: "/api/v1/farm", Status: 200}, time.Millisecond)
+	suite.recordRepo.failing = false
+	a.NoError(collector.Flush(), "should have no error when the repository recover")
+
+	_, counts := suite.recordRepo.snapshot()
+	a.Equal(uint(2), counts["/api/v1/farm"], "count of the failed flush should not be lost")
+}
+
+// Test new keys are dropped when the pending keys reach the limit while writing keeps failing
+func (suite *CollectorSuite) TestRecord_Limit() {
+	collector := suite.newCollector(3600, 1)
+	suite.recordRepo.failing = true
+	for i := 0; i < 15; i++ {
+		collector.Record(traffic.Key{BucketStart: hour, Route: fmt.Sprintf("/api/v1/farm/%d", i), Status: 200}, time.Millisecond)
+	}
+	collector.Record(traffic.Key{BucketStart: hour, Route: "/api/v1/farm/0", Status: 200}, time.Millisecond)
+
+	a := suite.Assert()
+	a.Error(collector.Flush(), "error of the repository should be returned")
+	a.Equal(uint(5), collector.TakeDropped(), "keys above ten times the max pending should be dropped")
+	a.Equal(uint(0), collector.TakeDropped(), "dropped count should be reset once taken")
+
+	suite.recordRepo.failing = false
+	a.NoError(collector.Flush(), "should have no error when the repository recover")
+	_, counts := suite.recordRepo.snapshot()
+	a.Len(counts, 10, "kept keys should be written")
+	a.Equal(uint(2), counts["/api/v1/farm/0"], "existing key should still be counted at the limit")
+}
+
+// Test the long value is cut to the column length without splitting multibyte character
+func (suite *CollectorSuite) TestRecord_Truncate() {
+	collector := suite.newCollector(3600, 0)
+	collector.Record(traffic.Key{BucketStart: hour, Route: "/" + strings.Repeat("é", 200), Status: 200}, time.Millisecond)
+	collector.Stop()
+
+	_, counts := suite.recordRepo.snapshot()
+	a := suite.Assert()
+	a.Len(counts, 1, "stopping collector that is not started should still drain it")
+	for path := range counts {
+		a.Equal(255, len(path), "route should be cut to the column length")
+		a.True(strings.HasSuffix(path, "é"), "multibyte character should not be split")
+	}
+}
+
+// Test requests are counted per hour with their latency
+func (suite *CollectorSuite) TestRecord_Bucket() {
+	collector := suite.newCollector(3600, 0)
+	key := traffic.Key{Method: "GET", Route: "/api/v1/farm/:farmId", Status: 200, UserAgent: "curl/7.81.0"}
+
+	key.BucketStart = hour.Add(5 * time.Minute)
+	collector.Record(key, 3*time.Millisecond)
+	key.BucketStart = hour.Add(59 * time.Minute)
+	collector.Record(key, 40*time.Millisecond)
+	key.BucketStart = hour.Add(time.Hour)
+	collector.Record(key, 700*time.Millisecond)
+	collector.Stop()
+
+	records := suite.recordRepo.records
+	sort.Slice(records, func(i, j int) bool { return records[i].BucketStart.Before(records[j].BucketStart) })
+
+	a := suite.Assert()
+	a.Len(records, 2, "requests in different hour should be in different bucket")
+	a.Equal(hour, records[0].BucketStart, "bucket should start at the hour")
+	a.Equal(uint(2), records[0].Count, "requests in the same hour should be counted together")
+	a.Equal(43.0, records[0].LatencySumMs, "latency should be summed")
+	a.Equal(40.0, records[0].LatencyMaxMs, "max latency should be kept")
+	a.Equal(uint(1), records[0].LatencyHistogram.Le5ms, "latency should be counted in its bucket")
+	a.Equal(uint(1), records[0].LatencyHistogram.Le50ms, "latency should be counted in its bucket")
+	a.Equal(uint(1), records[1].LatencyHistogram.Le1000ms, "latency should be counted in its bucket")
+}
@@ -0,0 +1,41 @@
+package traffic
+
+import (
+	"testing"
+
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/models"
+	"github.com/adiatma85/golang-rest-template-api/internal/pkg/traffic"
+	"github.com/stretchr/testify/suite"
+)
+
+type PercentileSuite struct {
+	suite.Suite
+}
+
+func TestPercentile(t *testing.T) {
+	suite.Run(t, new(PercentileSuite))
+}
+
+// Test percentile is interpolated inside the bucket of its rank
+func (suite *PercentileSuite) TestPercentile_Interpolated() {
+	// 10 requests up to 5ms, 10 requests in (10, 25]ms
+	histogram := models.LatencyHistogram{Le5ms: 10, Le25ms: 10}
+
+	a := suite.Assert()
+	a.InDelta(2.5, traffic.Percentile(histogram, 20, 25), 0.0001, "rank 5 should be in the middle of the first bucket")
+	a.InDelta(17.5, traffic.Percentile(histogram, 20, 75), 0.0001, "rank 15 should be in the middle of the third bucket")
+	a.InDelta(20, traffic.Percentile(histogram, 20, 99), 0.0001, "percentile should not exceed the max latency")
+}
+
+// Test the last bucket is bounded by the max latency
+func (suite *PercentileSuite) TestPercentile_Unbounded() {
+	histogram := models.LatencyHistogram{LeInf: 4}
+
+	a := suite.Assert()
+	a.InDelta(15000, traffic.Percentile(histogram, 20000, 50), 0.0001, "last bucket should be interpolated up to the max latency")
+}
+
+// Test empty histogram has no latency
+func (suite *PercentileSuite) TestPercentile_Empty() {
+	suite.Assert().Zero(traffic.Percentile(models.LatencyHistogram{}, 0, 99), "empty histogram should be zero")
+}